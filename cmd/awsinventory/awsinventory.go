@@ -1,9 +1,18 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/manywho/awsinventory/internal/awsdata"
 
 	"github.com/manywho/awsinventory/internal/inventory"
@@ -11,22 +20,81 @@ import (
 )
 
 var (
-	outputFile        string
-	regions, services []string
-	logLevel          string
-	printRegions      bool
-	printVersion      bool
+	configFile         string
+	outputFile         string
+	format             string
+	reportFile         string
+	mergeFile          string
+	mergeMode          string
+	regions, services  []string
+	allRegions         bool
+	logLevel           string
+	timeout            time.Duration
+	maxConcurrency     int
+	maxRetries         int
+	serviceConcurrency map[string]int
+	tagMapping         map[string]string
+	tagColumns         []string
+	includeTags        map[string]string
+	excludeTags        map[string]string
+	includeResources   []string
+	excludeResources   []string
+	accounts           []string
+	organization       bool
+	roleName           string
+	profile            string
+	roleARN            string
+	externalID         string
+	mfaSerial          string
+	caBundle           string
+	endpointURL        string
+	endpoints          map[string]string
+	printRegions       bool
+	printVersion       bool
 
 	version, build string
 )
 
 func init() {
-	pflag.StringVarP(&outputFile, "output-file", "o", "inventory.csv", "path to the output file")
+	pflag.StringVarP(&configFile, "config", "c", "", "path to a yaml or toml file setting any of these flags by name, which flags on the command line override")
+	pflag.StringVarP(&outputFile, "output-file", "o", "", "path to the output file (default inventory.<format>)")
+	pflag.StringVarP(&format, "format", "f", inventory.FormatCSV, fmt.Sprintf("format of the output file (%s)", strings.Join(inventory.Formats(), ",")))
+	pflag.StringVar(&mergeFile, "merge-file", "", "path to a csv, json or yaml inventory of assets to merge into the output, such as those outside of AWS")
+	pflag.StringVar(&mergeMode, "merge-mode", string(inventory.MergeSupplement), "whether merged rows override or supplement the fields of collected rows with the same unique asset identifier (override,supplement)")
+	pflag.StringVar(&reportFile, "report-file", "", "path to write a JSON report of the rows and errors for each service and region")
 	pflag.StringSliceVarP(&regions, "regions", "r", []string{}, "regions to gather data from")
-	pflag.StringSliceVarP(&services, "services", "s", []string{}, "services to gather data from")
+	pflag.BoolVar(&allRegions, "all-regions", false, "gather data from every region enabled for the account, including opted-in regions")
+	pflag.StringSliceVarP(&services, "services", "s", []string{}, fmt.Sprintf("services to gather data from (%s)", strings.Join(awsdata.Services(), ",")))
+	pflag.DurationVarP(&timeout, "timeout", "t", 0, "maximum time to spend gathering data, e.g. 30m (0 for no limit)")
+	pflag.IntVar(&maxConcurrency, "max-concurrency", 0, "maximum number of concurrent AWS API requests across all services (0 for no limit)")
+	pflag.IntVar(&maxRetries, "max-retries", awsdata.DefaultMaxRetries, "maximum number of times to retry a throttled AWS API request")
+	pflag.StringToIntVar(&serviceConcurrency, "service-concurrency", map[string]int{}, "maximum number of concurrent AWS API requests for individual services, e.g. ec2=5,s3=2, where ec2 covers the EC2 API requests of every service, including ebs, eip, eni and vpc")
+	pflag.StringToStringVar(&tagMapping, "tag-mapping", map[string]string{}, "fields to set from resource tags, as field=TagKey using the field names of the json format, e.g. system_administrator_owner=Owner")
+	pflag.StringSliceVar(&tagColumns, "tag-columns", []string{}, "keys of resource tags to add to the output, as columns in csv and xlsx or a tags object in json, e.g. Owner,CostCenter")
+	pflag.StringToStringVar(&includeTags, "include-tag", map[string]string{}, "only include resources with every one of these tags, as key=value where the value may be a glob pattern, e.g. fedramp-boundary=true")
+	pflag.StringToStringVar(&excludeTags, "exclude-tag", map[string]string{}, "leave out resources with any of these tags, as key=value where the value may be a glob pattern, e.g. environment=sandbox")
+	pflag.StringSliceVar(&includeResources, "include-resource", []string{}, "only include resources whose ID or ARN matches one of these glob patterns, e.g. arn:aws:s3:::prod-*")
+	pflag.StringSliceVar(&excludeResources, "exclude-resource", []string{}, "leave out resources whose ID or ARN matches any of these glob patterns")
+	pflag.StringVar(&profile, "profile", "", "name of the AWS shared config profile to use")
+	pflag.StringVar(&roleARN, "role-arn", "", "ARN of a role to assume before gathering data")
+	pflag.StringVar(&externalID, "external-id", "", "external ID to pass when assuming --role-arn or --role-name in each account")
+	pflag.StringVar(&mfaSerial, "mfa-serial", "", "serial number or ARN of the MFA device to use when assuming --role-arn")
+	pflag.StringVar(&caBundle, "ca-bundle", "", "path to a PEM file of certificate authorities to trust when connecting to AWS, instead of the system roots")
+	pflag.StringVar(&endpointURL, "endpoint-url", "", "URL to send every AWS API request to instead of AWS, e.g. http://localhost:4566 for LocalStack")
+	pflag.StringToStringVar(&endpoints, "endpoint", map[string]string{}, "URLs to send AWS API requests to for individual services, by service name or endpoint ID, e.g. efs=http://localhost:9000 or elasticfilesystem=http://localhost:9000")
+	pflag.StringSliceVar(&accounts, "accounts", []string{}, "IDs of accounts to gather data from by assuming --role-name in each")
+	pflag.BoolVar(&organization, "organization", false, "gather data from every active account in the AWS Organization by assuming --role-name in each")
+	pflag.StringVar(&roleName, "role-name", "OrganizationAccountAccessRole", "name of the role to assume in each account")
 	pflag.BoolVar(&printRegions, "print-regions", false, "prints the available AWS regions")
 	pflag.StringVarP(&logLevel, "log-level", "l", "warning", "set the level of log output")
 	pflag.BoolVarP(&printVersion, "version", "v", false, "prints the version information")
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Exit(runDiff(os.Args[2:]))
+	}
+
 	pflag.Parse()
 
 	if printVersion {
@@ -34,37 +102,259 @@ func init() {
 		os.Exit(0)
 	}
 
+	if configFile != "" {
+		if err := loadConfig(pflag.CommandLine, configFile); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load config: %s\n", err)
+			os.Exit(2)
+		}
+	}
+
 	initLogger()
-}
 
-func main() {
-	awsData := awsdata.New(logger, nil)
+	if allRegions && len(regions) > 0 {
+		logger.Fatal("--all-regions cannot be used with --regions")
+	}
+
+	opts := []awsdata.Option{
+		awsdata.WithMaxConcurrency(maxConcurrency),
+		awsdata.WithMaxRetries(maxRetries),
+		awsdata.WithTagMapping(tagMapping),
+	}
+	if allRegions {
+		opts = append(opts, awsdata.WithAllRegions())
+	}
+	for key, value := range includeTags {
+		opts = append(opts, awsdata.WithIncludeTag(key, value))
+	}
+	for key, value := range excludeTags {
+		opts = append(opts, awsdata.WithExcludeTag(key, value))
+	}
+	for _, pattern := range includeResources {
+		opts = append(opts, awsdata.WithIncludeResource(pattern))
+	}
+	for _, pattern := range excludeResources {
+		opts = append(opts, awsdata.WithExcludeResource(pattern))
+	}
+	for service, n := range serviceConcurrency {
+		opts = append(opts, awsdata.WithServiceConcurrency(service, n))
+	}
 
 	if printRegions {
-		awsData.PrintRegions()
+		awsdata.New(logger, nil).PrintRegions()
 		os.Exit(0)
 	}
 
+	defaultClients, err := awsdata.NewDefaultClients(awsdata.SessionOptions{
+		Profile:          profile,
+		RoleARN:          roleARN,
+		ExternalID:       externalID,
+		MFASerial:        mfaSerial,
+		MFATokenProvider: stscreds.StdinTokenProvider,
+		MaxRetries:       aws.Int(maxRetries),
+		EndpointURL:      endpointURL,
+		Endpoints:        endpoints,
+		CABundle:         caBundle,
+	})
+	if err != nil {
+		logger.Fatalf("failed to create AWS session: %s", err)
+	}
+
+	awsData := awsdata.New(logger, defaultClients, opts...)
+
+	var merger *inventory.Merger
+	if mergeFile != "" {
+		merger, err = newMerger()
+		if err != nil {
+			logger.Fatalf("failed to read %s: %s", mergeFile, err)
+		}
+	}
+
+	if outputFile == "" {
+		outputFile = "inventory." + format
+	}
+
 	f, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		logger.Fatal(err)
 	}
 	defer f.Close()
 
-	// Create new csv inventory
-	csv, err := inventory.NewCSV(f)
+	// Create new inventory in the output format, with columns for the accounts when gathering from several
+	writerOpts := []inventory.WriterOption{inventory.WithTagColumns(tagColumns...)}
+	if organization || len(accounts) > 0 {
+		writerOpts = append(writerOpts, inventory.WithAccountColumns())
+	}
+
+	output, err := inventory.NewWriter(format, f, writerOpts...)
 	if err != nil {
 		logger.Fatal(err)
 	}
 
-	// Write stored rows to csv inventory
-	var count int
-	awsData.Load(regions, services, func(row inventory.Row) error {
-		count++
-		return csv.WriteRow(row)
-	})
+	ctx, cancel := newContext()
+	defer cancel()
+
+	targets, err := getAccounts(ctx, awsData)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	// Write stored rows to the inventory
+	processRow := func(row inventory.Row) error {
+		if merger != nil {
+			var merged bool
+			if row, merged = merger.Merge(row); merged {
+				logger.Debugf("merged %s from %s", row.UniqueAssetIdentifier, mergeFile)
+			}
+		}
+
+		return output.WriteRow(row)
+	}
+
+	var reports []*awsdata.Report
+	if len(targets) == 0 {
+		reports = append(reports, awsData.Load(ctx, regions, services, processRow))
+	}
+
+	for _, account := range targets {
+		logger.Infof("gathering data from account %s", account.ID)
+
+		clients := defaultClients.AssumeRole(account.RoleARN(partitionRegion(), roleName))
+
+		if account.Alias == "" {
+			alias, err := awsdata.New(logger, clients, opts...).AccountAlias(ctx)
+			if err != nil {
+				logger.Warningf("failed to get alias of account %s: %s", account.ID, err)
+			}
+			account.Alias = alias
+		}
+
+		accountData := awsdata.New(logger, clients, append(opts, awsdata.WithAccount(account))...)
+
+		reports = append(reports, accountData.Load(ctx, regions, services, processRow))
+	}
 
 	// Write file to disk
+	var count, filtered, unavailable, errs int
+	for _, report := range reports {
+		count += report.Count()
+		filtered += report.FilteredCount()
+		errs += len(report.Errors)
+
+		for _, regions := range report.Unavailable {
+			unavailable += len(regions)
+		}
+
+		for service, n := range report.Retries {
+			logger.Infof("retried %d throttled requests for %s", n, service)
+		}
+	}
+
+	if filtered > 0 {
+		logger.Infof("left out %d rows filtered by resource or tag", filtered)
+	}
+
+	if unavailable > 0 {
+		logger.Infof("skipped %d services in regions where they are not available", unavailable)
+	}
+
+	if merger != nil {
+		for _, row := range merger.Remaining() {
+			if err := output.WriteRow(row); err != nil {
+				logger.Fatalf("failed to write %s: %s", outputFile, err)
+			}
+			count++
+		}
+	}
+
 	logger.Infof("writing %d rows to %s", count, outputFile)
-	csv.Flush()
+	if err := output.Close(); err != nil {
+		logger.Fatalf("failed to write %s: %s", outputFile, err)
+	}
+
+	if reportFile != "" {
+		var err error
+		if len(targets) == 0 {
+			err = writeReport(reports[0])
+		} else {
+			err = writeReport(reports)
+		}
+		if err != nil {
+			logger.Errorf("failed to write report: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	if errs > 0 {
+		logger.Errorf("inventory is incomplete, %d errors encountered", errs)
+		os.Exit(1)
+	}
+}
+
+// newMerger returns a merger for the rows in the merge file
+func newMerger() (*inventory.Merger, error) {
+	rows, err := readInventory(mergeFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.NewMerger(rows, inventory.MergeMode(mergeMode))
+}
+
+// getAccounts returns the accounts to gather data from, either those given with --accounts or every account in the
+// organization. No accounts means data is gathered from the account of the default credentials.
+func getAccounts(ctx context.Context, awsData *awsdata.AWSData) ([]awsdata.Account, error) {
+	if organization {
+		return awsData.ListAccounts(ctx)
+	}
+
+	var targets []awsdata.Account
+	for _, id := range accounts {
+		targets = append(targets, awsdata.Account{ID: id})
+	}
+
+	return targets, nil
+}
+
+// partitionRegion returns the region used to work out the partition of the roles to assume
+func partitionRegion() string {
+	if len(regions) > 0 {
+		return regions[0]
+	}
+
+	return awsdata.DefaultRegion
+}
+
+// writeReport writes the report, or reports when gathering data from multiple accounts, as JSON to the report file
+func writeReport(report interface{}) error {
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(reportFile, b, 0644)
+}
+
+// newContext returns a context which is cancelled on an interrupt or once the timeout passes
+func newContext() (context.Context, context.CancelFunc) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-signals:
+			logger.Warningf("received %s, stopping", sig)
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(signals)
+	}()
+
+	return ctx, cancel
 }
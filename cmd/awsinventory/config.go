@@ -0,0 +1,239 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// configIgnoredFlags are the flags which cannot be set in a config file
+var configIgnoredFlags = []string{"config", "help", "print-regions", "version"}
+
+// configError is an error in the value of a key in a config file
+type configError struct {
+	File string
+	// Line is the line of the key, or zero when it is not known
+	Line int
+	Key  string
+	Err  error
+}
+
+func (e *configError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s", e.File, e.Line, e.Key, e.Err)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", e.File, e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *configError) Unwrap() error {
+	return e.Err
+}
+
+// configEntry is a key in a config file with its value
+type configEntry struct {
+	Key   string
+	Line  int
+	Value interface{}
+}
+
+// loadConfig sets the flags from the keys of a yaml or toml config file, chosen by its extension.
+// Keys are the names of the flags, and flags given on the command line keep their values.
+func loadConfig(flags *pflag.FlagSet, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var entries []configEntry
+	switch filepath.Ext(path) {
+	case ".toml":
+		entries, err = readTOMLConfig(f)
+	default:
+		entries, err = readYAMLConfig(f)
+	}
+	if err != nil {
+		var cerr *configError
+		if errors.As(err, &cerr) {
+			cerr.File = path
+			return cerr
+		}
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, entry := range entries {
+		if err := setFlagFromConfig(flags, entry); err != nil {
+			return &configError{File: path, Line: entry.Line, Key: entry.Key, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// readYAMLConfig returns the keys of a yaml config file in the order they are written
+func readYAMLConfig(r io.Reader) ([]configEntry, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping of flag names to values", root.Line)
+	}
+
+	var entries []configEntry
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+
+		entry := configEntry{
+			Key:  key.Value,
+			Line: key.Line,
+		}
+		if err := value.Decode(&entry.Value); err != nil {
+			return nil, &configError{Line: key.Line, Key: key.Value, Err: err}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// readTOMLConfig returns the keys of a toml config file in alphabetical order
+func readTOMLConfig(r io.Reader) ([]configEntry, error) {
+	var values map[string]interface{}
+	if _, err := toml.DecodeReader(r, &values); err != nil {
+		return nil, err
+	}
+
+	var entries []configEntry
+	for key, value := range values {
+		entries = append(entries, configEntry{
+			Key:   key,
+			Value: value,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	return entries, nil
+}
+
+// setFlagFromConfig sets the flag named by the entry's key to its value, unless the flag was given on the command line.
+// Lists may only be given for flags taking several values, and mappings for flags taking key=value pairs.
+func setFlagFromConfig(flags *pflag.FlagSet, entry configEntry) error {
+	flag := flags.Lookup(entry.Key)
+	if flag == nil || stringInSlice(entry.Key, configIgnoredFlags) {
+		return errors.New("unknown key")
+	}
+
+	if flag.Changed {
+		return nil
+	}
+
+	flagType := flag.Value.Type()
+	isMap := strings.HasPrefix(flagType, "stringTo")
+	isSlice := strings.HasSuffix(flagType, "Slice") || strings.HasSuffix(flagType, "Array")
+
+	var values []string
+	switch v := entry.Value.(type) {
+	case map[string]interface{}:
+		if !isMap {
+			return fmt.Errorf("expected a %s, not a mapping", flagType)
+		}
+
+		var keys []string
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			value, err := configScalar(v[key])
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			values = append(values, csvQuote(key+"="+value))
+		}
+	case []interface{}:
+		if !isSlice {
+			return fmt.Errorf("expected a %s, not a list", flagType)
+		}
+
+		for _, item := range v {
+			value, err := configScalar(item)
+			if err != nil {
+				return err
+			}
+			values = append(values, csvQuote(value))
+		}
+	default:
+		if isMap {
+			return fmt.Errorf("expected a mapping, not %v", v)
+		}
+
+		value, err := configScalar(v)
+		if err != nil {
+			return err
+		}
+		if isSlice {
+			value = csvQuote(value)
+		}
+		values = append(values, value)
+	}
+
+	for _, value := range values {
+		if err := flags.Set(entry.Key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// configScalar returns a single value from a config file as a string, as it would be given on the command line
+func configScalar(v interface{}) (string, error) {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return "", errors.New("expected a single value")
+	case nil:
+		return "", nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// csvQuote quotes a value holding commas or quotes, so pflag does not split it into several values
+func csvQuote(s string) string {
+	if !strings.ContainsAny(s, ",\"") {
+		return s
+	}
+
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func stringInSlice(needle string, haystack []string) bool {
+	for _, s := range haystack {
+		if needle == s {
+			return true
+		}
+	}
+
+	return false
+}
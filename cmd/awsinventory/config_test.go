@@ -0,0 +1,128 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfigFlags struct {
+	regions    []string
+	format     string
+	timeout    time.Duration
+	tagMapping map[string]string
+	include    []string
+	accounts   []string
+}
+
+func newTestConfigFlags() (*pflag.FlagSet, *testConfigFlags) {
+	var f testConfigFlags
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringSliceVar(&f.regions, "regions", []string{}, "")
+	flags.StringVar(&f.format, "format", "csv", "")
+	flags.DurationVar(&f.timeout, "timeout", 0, "")
+	flags.StringToStringVar(&f.tagMapping, "tag-mapping", map[string]string{}, "")
+	flags.StringSliceVar(&f.include, "include-resource", []string{}, "")
+	flags.StringSliceVar(&f.accounts, "accounts", []string{}, "")
+	flags.Bool("version", false, "")
+
+	return flags, &f
+}
+
+func writeTestConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+	return path
+}
+
+func TestLoadConfigSetsFlagsFromYAML(t *testing.T) {
+	flags, f := newTestConfigFlags()
+	path := writeTestConfig(t, "awsinventory.yaml", `
+regions:
+  - us-east-1
+  - eu-west-2
+format: xlsx
+timeout: 30m
+tag-mapping:
+  system_administrator_owner: Owner
+include-resource: "arn:aws:s3:::a,b*"
+`)
+
+	require.NoError(t, loadConfig(flags, path))
+
+	require.Equal(t, []string{"us-east-1", "eu-west-2"}, f.regions)
+	require.Equal(t, "xlsx", f.format)
+	require.Equal(t, 30*time.Minute, f.timeout)
+	require.Equal(t, map[string]string{"system_administrator_owner": "Owner"}, f.tagMapping)
+	require.Equal(t, []string{"arn:aws:s3:::a,b*"}, f.include)
+}
+
+func TestLoadConfigSetsFlagsFromTOML(t *testing.T) {
+	flags, f := newTestConfigFlags()
+	path := writeTestConfig(t, "awsinventory.toml", `
+regions = ["us-east-1"]
+format = "json"
+accounts = [123456789012]
+
+[tag-mapping]
+function = "Function"
+`)
+
+	require.NoError(t, loadConfig(flags, path))
+
+	require.Equal(t, []string{"us-east-1"}, f.regions)
+	require.Equal(t, "json", f.format)
+	require.Equal(t, []string{"123456789012"}, f.accounts)
+	require.Equal(t, map[string]string{"function": "Function"}, f.tagMapping)
+}
+
+func TestLoadConfigKeepsFlagsFromCommandLine(t *testing.T) {
+	flags, f := newTestConfigFlags()
+	require.NoError(t, flags.Parse([]string{"--format", "jsonl"}))
+
+	path := writeTestConfig(t, "awsinventory.yaml", "format: xlsx\nregions: [us-east-1]\n")
+
+	require.NoError(t, loadConfig(flags, path))
+
+	require.Equal(t, "jsonl", f.format)
+	require.Equal(t, []string{"us-east-1"}, f.regions)
+}
+
+func TestLoadConfigReturnsErrorForUnknownKey(t *testing.T) {
+	flags, _ := newTestConfigFlags()
+	path := writeTestConfig(t, "awsinventory.yaml", "format: xlsx\nregion: us-east-1\n")
+
+	err := loadConfig(flags, path)
+
+	require.EqualError(t, err, path+":2: region: unknown key")
+}
+
+func TestLoadConfigReturnsErrorForIgnoredFlag(t *testing.T) {
+	flags, _ := newTestConfigFlags()
+	path := writeTestConfig(t, "awsinventory.yaml", "version: true\n")
+
+	require.EqualError(t, loadConfig(flags, path), path+":1: version: unknown key")
+}
+
+func TestLoadConfigReturnsErrorForWrongType(t *testing.T) {
+	flags, _ := newTestConfigFlags()
+	path := writeTestConfig(t, "awsinventory.yaml", "format:\n  - csv\n  - json\n")
+
+	require.EqualError(t, loadConfig(flags, path), path+":1: format: expected a string, not a list")
+}
+
+func TestLoadConfigReturnsErrorForInvalidValue(t *testing.T) {
+	flags, _ := newTestConfigFlags()
+	path := writeTestConfig(t, "awsinventory.toml", `timeout = "soon"`)
+
+	err := loadConfig(flags, path)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), path+": timeout: ")
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/manywho/awsinventory/internal/inventory"
+	"github.com/spf13/pflag"
+)
+
+const diffUsage = `Usage: awsinventory diff [flags] OLD NEW
+
+Compares two inventories written by awsinventory, in csv, json, jsonl or yaml format,
+and lists the assets added, removed and modified in NEW.
+
+Flags:
+`
+
+// runDiff runs the diff subcommand with the given arguments, returning the exit status
+func runDiff(args []string) int {
+	flags := pflag.NewFlagSet("diff", pflag.ContinueOnError)
+	diffFormat := flags.StringP("format", "f", "text", "format of the differences (text,csv,json)")
+	diffOutputFile := flags.StringP("output-file", "o", "", "path to write the differences to (default stdout)")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, diffUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if err == pflag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	oldRows, err := readInventory(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read %s: %s\n", flags.Arg(0), err)
+		return 1
+	}
+
+	newRows, err := readInventory(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read %s: %s\n", flags.Arg(1), err)
+		return 1
+	}
+
+	var write func(d *inventory.Diff, w io.Writer) error
+	switch *diffFormat {
+	case "text":
+		write = (*inventory.Diff).WriteText
+	case "csv":
+		write = (*inventory.Diff).WriteCSV
+	case "json":
+		write = (*inventory.Diff).WriteJSON
+	default:
+		fmt.Fprintf(os.Stderr, "unknown diff format %s\n", *diffFormat)
+		return 2
+	}
+
+	out := os.Stdout
+	if *diffOutputFile != "" {
+		out, err = os.OpenFile(*diffOutputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer out.Close()
+	}
+
+	if err := write(inventory.Compare(oldRows, newRows), out); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write differences: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// readInventory reads the rows of an inventory, choosing the format from the file extension
+func readInventory(path string) ([]inventory.Row, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch filepath.Ext(path) {
+	case "." + inventory.FormatJSON, "." + inventory.FormatJSONL:
+		return inventory.ReadJSON(f)
+	case ".yaml", ".yml":
+		return inventory.ReadYAML(f)
+	default:
+		return inventory.ReadCSV(f)
+	}
+}
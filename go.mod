@@ -3,8 +3,10 @@ module github.com/manywho/awsinventory
 go 1.12
 
 require (
+	github.com/BurntSushi/toml v0.3.0
 	github.com/aws/aws-sdk-go v1.38.0
 	github.com/sirupsen/logrus v1.8.1
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,104 @@
+package awsdata
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/sirupsen/logrus"
+)
+
+// Account is an AWS account to load data from
+type Account struct {
+	ID    string `json:"id"`
+	Alias string `json:"alias,omitempty"`
+}
+
+// RoleARN returns the ARN of the named role in the account, in the partition of the given region
+func (a Account) RoleARN(region, roleName string) string {
+	partition := endpoints.AwsPartitionID
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		partition = p.ID()
+	}
+
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, a.ID, roleName)
+}
+
+// ListAccounts returns the active accounts in the organization of the account the clients belong to,
+// using the account names as their aliases
+func (d *AWSData) ListAccounts(ctx context.Context) ([]Account, error) {
+	if err := d.initClients(); err != nil {
+		return nil, err
+	}
+
+	organizationsSvc := d.clients.GetOrganizationsClient(DefaultRegion)
+
+	log := d.log.WithFields(logrus.Fields{
+		"region":  RegionGlobal,
+		"service": "organizations",
+	})
+
+	log.Info("loading accounts")
+
+	var accounts []Account
+	done := false
+	params := &organizations.ListAccountsInput{}
+	for !done {
+		var out *organizations.ListAccountsOutput
+		err := d.retry(ctx, "organizations", func() (err error) {
+			out, err = organizationsSvc.ListAccountsWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list accounts: %w", err)
+		}
+
+		for _, a := range out.Accounts {
+			if aws.StringValue(a.Status) != organizations.AccountStatusActive {
+				continue
+			}
+
+			accounts = append(accounts, Account{
+				ID:    aws.StringValue(a.Id),
+				Alias: aws.StringValue(a.Name),
+			})
+		}
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	log.Infof("found %d accounts", len(accounts))
+
+	return accounts, nil
+}
+
+// AccountAlias returns the IAM alias of the account the clients belong to, or an empty string if it has none
+func (d *AWSData) AccountAlias(ctx context.Context) (string, error) {
+	if err := d.initClients(); err != nil {
+		return "", err
+	}
+
+	iamSvc := d.clients.GetIAMClient(DefaultRegion)
+
+	var out *iam.ListAccountAliasesOutput
+	err := d.retry(ctx, ServiceIAM, func() (err error) {
+		out, err = iamSvc.ListAccountAliasesWithContext(ctx, &iam.ListAccountAliasesInput{})
+		return
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list account aliases: %w", err)
+	}
+
+	if len(out.AccountAliases) == 0 {
+		return "", nil
+	}
+
+	return aws.StringValue(out.AccountAliases[0]), nil
+}
@@ -0,0 +1,140 @@
+package awsdata_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+var testAccounts = []Account{
+	{
+		ID:    "111111111111",
+		Alias: "test-account-1",
+	},
+	{
+		ID:    "222222222222",
+		Alias: "test-account-2",
+	},
+}
+
+// Test Data
+var testOrganizationsListAccountsOutputPage1 = &organizations.ListAccountsOutput{
+	NextToken: aws.String(testAccounts[1].ID),
+	Accounts: []*organizations.Account{
+		{
+			Id:     aws.String(testAccounts[0].ID),
+			Name:   aws.String(testAccounts[0].Alias),
+			Status: aws.String(organizations.AccountStatusActive),
+		},
+		{
+			Id:     aws.String("333333333333"),
+			Name:   aws.String("test-account-suspended"),
+			Status: aws.String(organizations.AccountStatusSuspended),
+		},
+	},
+}
+
+var testOrganizationsListAccountsOutputPage2 = &organizations.ListAccountsOutput{
+	Accounts: []*organizations.Account{
+		{
+			Id:     aws.String(testAccounts[1].ID),
+			Name:   aws.String(testAccounts[1].Alias),
+			Status: aws.String(organizations.AccountStatusActive),
+		},
+	},
+}
+
+// Mocks
+type OrganizationsMock struct {
+	organizationsiface.OrganizationsAPI
+}
+
+func (e OrganizationsMock) ListAccountsWithContext(ctx aws.Context, cfg *organizations.ListAccountsInput, opts ...request.Option) (*organizations.ListAccountsOutput, error) {
+	if cfg.NextToken == nil {
+		return testOrganizationsListAccountsOutputPage1, nil
+	}
+
+	return testOrganizationsListAccountsOutputPage2, nil
+}
+
+type OrganizationsErrorMock struct {
+	organizationsiface.OrganizationsAPI
+}
+
+func (e OrganizationsErrorMock) ListAccountsWithContext(ctx aws.Context, cfg *organizations.ListAccountsInput, opts ...request.Option) (*organizations.ListAccountsOutput, error) {
+	return nil, testError
+}
+
+type IAMAccountAliasMock struct {
+	iamiface.IAMAPI
+}
+
+func (e IAMAccountAliasMock) ListAccountAliasesWithContext(ctx aws.Context, cfg *iam.ListAccountAliasesInput, opts ...request.Option) (*iam.ListAccountAliasesOutput, error) {
+	return &iam.ListAccountAliasesOutput{
+		AccountAliases: aws.StringSlice([]string{testAccounts[0].Alias}),
+	}, nil
+}
+
+// Tests
+func TestCanListAccounts(t *testing.T) {
+	d := New(logrus.New(), TestClients{Organizations: OrganizationsMock{}})
+
+	accounts, err := d.ListAccounts(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, testAccounts, accounts)
+}
+
+func TestListAccountsReturnsErrors(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{Organizations: OrganizationsErrorMock{}})
+
+	_, err := d.ListAccounts(context.Background())
+
+	require.True(t, errors.Is(err, testError))
+}
+
+func TestCanGetAccountAlias(t *testing.T) {
+	d := New(logrus.New(), TestClients{IAM: IAMAccountAliasMock{}})
+
+	alias, err := d.AccountAlias(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, testAccounts[0].Alias, alias)
+}
+
+func TestAccountCanReturnRoleARN(t *testing.T) {
+	require.Equal(t, "arn:aws:iam::111111111111:role/test-role", testAccounts[0].RoleARN(DefaultRegion, "test-role"))
+	require.Equal(t, "arn:aws-us-gov:iam::111111111111:role/test-role", testAccounts[0].RoleARN("us-gov-west-1", "test-role"))
+}
+
+func TestLoadAddsAccountToRows(t *testing.T) {
+	d := New(logrus.New(), TestClients{}, WithAccount(testAccounts[0]))
+
+	var rows []inventory.Row
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{testServiceCustom}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	expected := testCustomRow
+	expected.AccountID = testAccounts[0].ID
+	expected.AccountAlias = testAccounts[0].Alias
+
+	require.Equal(t, []inventory.Row{expected}, rows)
+	require.Equal(t, &testAccounts[0], report.Account)
+}
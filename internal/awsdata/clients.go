@@ -1,7 +1,13 @@
 package awsdata
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
@@ -15,6 +21,10 @@ import (
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
 	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
 	"github.com/aws/aws-sdk-go/service/elasticsearchservice"
@@ -23,12 +33,16 @@ import (
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/fsx"
+	"github.com/aws/aws-sdk-go/service/fsx/fsxiface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/aws/aws-sdk-go/service/route53"
@@ -37,6 +51,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/aws/aws-sdk-go/service/sts"
 )
 
 // Clients is an interface for getting new AWS service clients
@@ -47,13 +62,17 @@ type Clients interface {
 	GetEC2Client(region string) ec2iface.EC2API
 	GetECRClient(region string) ecriface.ECRAPI
 	GetECSClient(region string) ecsiface.ECSAPI
+	GetEFSClient(region string) efsiface.EFSAPI
+	GetEKSClient(region string) eksiface.EKSAPI
 	GetElastiCacheClient(region string) elasticacheiface.ElastiCacheAPI
 	GetElasticsearchServiceClient(region string) elasticsearchserviceiface.ElasticsearchServiceAPI
 	GetELBClient(region string) elbiface.ELBAPI
 	GetELBV2Client(region string) elbv2iface.ELBV2API
+	GetFSxClient(region string) fsxiface.FSxAPI
 	GetIAMClient(region string) iamiface.IAMAPI
 	GetKMSClient(region string) kmsiface.KMSAPI
 	GetLambdaClient(region string) lambdaiface.LambdaAPI
+	GetOrganizationsClient(region string) organizationsiface.OrganizationsAPI
 	GetRDSClient(region string) rdsiface.RDSAPI
 	GetRoute53Client(region string) route53iface.Route53API
 	GetS3Client(region string) s3iface.S3API
@@ -61,94 +80,332 @@ type Clients interface {
 }
 
 // DefaultClients holds the default methods for creating AWS service clients
-type DefaultClients struct{}
+type DefaultClients struct {
+	sess        *session.Session
+	externalID  string
+	maxRetries  int
+	endpointURL string
+	endpoints   map[string]string
+}
+
+// SessionOptions configures the session used by DefaultClients
+type SessionOptions struct {
+	// Profile is the name of the shared config profile to use, instead of the default profile
+	Profile string
+
+	// RoleARN is the ARN of a role to assume using the credentials from the profile
+	RoleARN string
+
+	// ExternalID is passed when assuming RoleARN or the roles given to AssumeRole, for roles which require one
+	ExternalID string
+
+	// MFASerial is the serial number or ARN of the MFA device to use when assuming RoleARN
+	MFASerial string
+
+	// MFATokenProvider returns MFA tokens when assuming a role which requires one,
+	// whether that is RoleARN or a role in the profile
+	MFATokenProvider func() (string, error)
+
+	// CABundle is the path to a PEM file of certificate authorities to trust, instead of the system roots
+	CABundle string
+
+	// MaxRetries is the number of times the clients retry a failed request, backing off exponentially with jitter.
+	// Nil means DefaultMaxRetries. Throttled requests are not retried by the clients, but by AWSData.Load.
+	MaxRetries *int
+
+	// EndpointURL is sent every request instead of the AWS endpoints, for stand-ins such as LocalStack
+	EndpointURL string
+
+	// Endpoints overrides EndpointURL for individual services, keyed by their endpoint ID such as elasticfilesystem
+	// or by the name of their service such as efs. Keys which are neither give an error wrapping ErrInvalidEndpoint.
+	Endpoints map[string]string
+}
+
+// NewDefaultClients returns new DefaultClients using a session configured with the given options
+func NewDefaultClients(opts SessionOptions) (*DefaultClients, error) {
+	sessOpts := session.Options{
+		Profile:                 opts.Profile,
+		SharedConfigState:       session.SharedConfigEnable,
+		AssumeRoleTokenProvider: opts.MFATokenProvider,
+	}
+
+	if opts.CABundle != "" {
+		f, err := os.Open(opts.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open CA bundle: %w", err)
+		}
+		defer f.Close()
+
+		sessOpts.CustomCABundle = f
+	}
 
-// Default session options
-var sess = session.Must(session.NewSessionWithOptions(session.Options{
-	SharedConfigState: session.SharedConfigEnable,
-}))
+	endpoints, err := resolveEndpoints(opts.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := session.NewSessionWithOptions(sessOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	maxRetries := DefaultMaxRetries
+	if opts.MaxRetries != nil {
+		maxRetries = *opts.MaxRetries
+	}
+
+	c := &DefaultClients{
+		sess:        sess,
+		externalID:  opts.ExternalID,
+		maxRetries:  maxRetries,
+		endpointURL: opts.EndpointURL,
+		endpoints:   endpoints,
+	}
+
+	if opts.RoleARN != "" {
+		c.sess = sess.Copy(&aws.Config{
+			Credentials: stscreds.NewCredentials(c.stsConfigProvider(), opts.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+				c.setExternalID(p)
+
+				if opts.MFASerial != "" {
+					p.SerialNumber = aws.String(opts.MFASerial)
+					p.TokenProvider = opts.MFATokenProvider
+				}
+			}),
+		})
+	}
+
+	return c, nil
+}
 
 // GetCloudFrontClient returns a new CloudFront client for the given region
 func (c DefaultClients) GetCloudFrontClient(region string) cloudfrontiface.CloudFrontAPI {
-	return cloudfront.New(sess, &aws.Config{Region: aws.String(region)})
+	return cloudfront.New(c.sess, c.config(cloudfront.EndpointsID, region))
 }
 
 // GetCodeCommitClient returns a new CodeCommit client for the given region
 func (c DefaultClients) GetCodeCommitClient(region string) codecommitiface.CodeCommitAPI {
-	return codecommit.New(sess, &aws.Config{Region: aws.String(region)})
+	return codecommit.New(c.sess, c.config(codecommit.EndpointsID, region))
 }
 
 // GetDynamoDBClient returns a new DynamoDB client for the given region
 func (c DefaultClients) GetDynamoDBClient(region string) dynamodbiface.DynamoDBAPI {
-	return dynamodb.New(sess, &aws.Config{Region: aws.String(region)})
+	return dynamodb.New(c.sess, c.config(dynamodb.EndpointsID, region))
 }
 
 // GetEC2Client returns a new EC2 client for the given region
 func (c DefaultClients) GetEC2Client(region string) ec2iface.EC2API {
-	return ec2.New(sess, &aws.Config{Region: aws.String(region)})
+	return ec2.New(c.sess, c.config(ec2.EndpointsID, region))
 }
 
 // GetECRClient returns a new ECS client for the given region
 func (c DefaultClients) GetECRClient(region string) ecriface.ECRAPI {
-	return ecr.New(sess, &aws.Config{Region: aws.String(region)})
+	return ecr.New(c.sess, c.config(ecr.EndpointsID, region))
 }
 
 // GetECSClient returns a new ECS client for the given region
 func (c DefaultClients) GetECSClient(region string) ecsiface.ECSAPI {
-	return ecs.New(sess, &aws.Config{Region: aws.String(region)})
+	return ecs.New(c.sess, c.config(ecs.EndpointsID, region))
+}
+
+// GetEFSClient returns a new EFS client for the given region
+func (c DefaultClients) GetEFSClient(region string) efsiface.EFSAPI {
+	return efs.New(c.sess, c.config(efs.EndpointsID, region))
+}
+
+// GetEKSClient returns a new EKS client for the given region
+func (c DefaultClients) GetEKSClient(region string) eksiface.EKSAPI {
+	return eks.New(c.sess, c.config(eks.EndpointsID, region))
 }
 
 // GetElastiCacheClient returns a new ElastiCache client for the given region
 func (c DefaultClients) GetElastiCacheClient(region string) elasticacheiface.ElastiCacheAPI {
-	return elasticache.New(sess, &aws.Config{Region: aws.String(region)})
+	return elasticache.New(c.sess, c.config(elasticache.EndpointsID, region))
 }
 
 // GetElasticsearchServiceClient returns a new ElasticsearchService client for the given region
 func (c DefaultClients) GetElasticsearchServiceClient(region string) elasticsearchserviceiface.ElasticsearchServiceAPI {
-	return elasticsearchservice.New(sess, &aws.Config{Region: aws.String(region)})
+	return elasticsearchservice.New(c.sess, c.config(elasticsearchservice.EndpointsID, region))
 }
 
 // GetELBClient returns a new ELB client for the given region
 func (c DefaultClients) GetELBClient(region string) elbiface.ELBAPI {
-	return elb.New(sess, &aws.Config{Region: aws.String(region)})
+	return elb.New(c.sess, c.config(elb.EndpointsID, region))
 }
 
 // GetELBV2Client returns a new ELBV2 client for the given region
 func (c DefaultClients) GetELBV2Client(region string) elbv2iface.ELBV2API {
-	return elbv2.New(sess, &aws.Config{Region: aws.String(region)})
+	return elbv2.New(c.sess, c.config(elbv2.EndpointsID, region))
+}
+
+// GetFSxClient returns a new FSx client for the given region
+func (c DefaultClients) GetFSxClient(region string) fsxiface.FSxAPI {
+	return fsx.New(c.sess, c.config(fsx.EndpointsID, region))
 }
 
 // GetIAMClient returns a new IAM client for the given region
 func (c DefaultClients) GetIAMClient(region string) iamiface.IAMAPI {
-	return iam.New(sess, &aws.Config{Region: aws.String(region)})
+	return iam.New(c.sess, c.config(iam.EndpointsID, region))
 }
 
 // GetKMSClient returns a new KMS client for the given region
 func (c DefaultClients) GetKMSClient(region string) kmsiface.KMSAPI {
-	return kms.New(sess, &aws.Config{Region: aws.String(region)})
+	return kms.New(c.sess, c.config(kms.EndpointsID, region))
 }
 
 // GetLambdaClient returns a new RDS client for the given region
 func (c DefaultClients) GetLambdaClient(region string) lambdaiface.LambdaAPI {
-	return lambda.New(sess, &aws.Config{Region: aws.String(region)})
+	return lambda.New(c.sess, c.config(lambda.EndpointsID, region))
+}
+
+// GetOrganizationsClient returns a new Organizations client for the given region
+func (c DefaultClients) GetOrganizationsClient(region string) organizationsiface.OrganizationsAPI {
+	return organizations.New(c.sess, c.config(organizations.EndpointsID, region))
 }
 
 // GetRDSClient returns a new RDS client for the given region
 func (c DefaultClients) GetRDSClient(region string) rdsiface.RDSAPI {
-	return rds.New(sess, &aws.Config{Region: aws.String(region)})
+	return rds.New(c.sess, c.config(rds.EndpointsID, region))
 }
 
 // GetRoute53Client returns a new Route53 client for the given region
 func (c DefaultClients) GetRoute53Client(region string) route53iface.Route53API {
-	return route53.New(sess, &aws.Config{Region: aws.String(region)})
+	return route53.New(c.sess, c.config(route53.EndpointsID, region))
 }
 
 // GetS3Client returns a new S3 client for the given region
 func (c DefaultClients) GetS3Client(region string) s3iface.S3API {
-	return s3.New(sess, &aws.Config{Region: aws.String(region)})
+	return s3.New(c.sess, c.config(s3.EndpointsID, region))
 }
 
 // GetSQSClient returns a new SQS client for the given region
 func (c DefaultClients) GetSQSClient(region string) sqsiface.SQSAPI {
-	return sqs.New(sess, &aws.Config{Region: aws.String(region)})
+	return sqs.New(c.sess, c.config(sqs.EndpointsID, region))
+}
+
+// AssumeRole returns a copy of the clients which use credentials from assuming the role with the given ARN,
+// passing the external ID the clients were created with, if any
+func (c DefaultClients) AssumeRole(roleARN string) *DefaultClients {
+	c.sess = c.sess.Copy(&aws.Config{
+		Credentials: stscreds.NewCredentials(c.stsConfigProvider(), roleARN, c.setExternalID),
+	})
+
+	return &c
+}
+
+// setExternalID sets the external ID the clients were created with, if any, on a role provider
+func (c DefaultClients) setExternalID(p *stscreds.AssumeRoleProvider) {
+	if c.externalID != "" {
+		p.ExternalID = aws.String(c.externalID)
+	}
+}
+
+// config returns the configuration shared by all clients for the given service endpoint ID and region
+func (c DefaultClients) config(endpointsID, region string) *aws.Config {
+	cfg := aws.NewConfig().WithRegion(region)
+
+	if endpoint := c.endpoint(endpointsID); endpoint != "" {
+		cfg = cfg.WithEndpoint(endpoint)
+
+		// Stand-ins serve every bucket from the one endpoint, rather than a subdomain per bucket
+		if endpointsID == s3.EndpointsID {
+			cfg = cfg.WithS3ForcePathStyle(true)
+		}
+	}
+
+	return request.WithRetryer(cfg, sdkRetryer{
+		DefaultRetryer: client.DefaultRetryer{
+			NumMaxRetries: c.maxRetries,
+		},
+	})
+}
+
+// sdkRetryer retries failed requests the same way as client.DefaultRetryer, except for throttled requests.
+// Those are left to AWSData.retry, so that each is only retried once and counted in the report.
+type sdkRetryer struct {
+	client.DefaultRetryer
+}
+
+// ShouldRetry returns false for throttled requests, otherwise deferring to client.DefaultRetryer
+func (r sdkRetryer) ShouldRetry(req *request.Request) bool {
+	if request.IsErrorThrottle(req.Error) {
+		return false
+	}
+
+	return r.DefaultRetryer.ShouldRetry(req)
+}
+
+// clientEndpointsIDs holds the endpoint IDs of every client DefaultClients creates
+var clientEndpointsIDs = []string{
+	cloudfront.EndpointsID,
+	codecommit.EndpointsID,
+	dynamodb.EndpointsID,
+	ec2.EndpointsID,
+	ecr.EndpointsID,
+	ecs.EndpointsID,
+	efs.EndpointsID,
+	eks.EndpointsID,
+	elasticache.EndpointsID,
+	elasticsearchservice.EndpointsID,
+	elb.EndpointsID,
+	elbv2.EndpointsID,
+	fsx.EndpointsID,
+	iam.EndpointsID,
+	kms.EndpointsID,
+	lambda.EndpointsID,
+	organizations.EndpointsID,
+	rds.EndpointsID,
+	route53.EndpointsID,
+	s3.EndpointsID,
+	sqs.EndpointsID,
+	sts.EndpointsID,
+}
+
+// resolveEndpoints returns the endpoint overrides keyed by endpoint ID, replacing the names of services with the
+// endpoint IDs of their collectors. Keys which are neither the endpoint ID of a client nor the name of a service
+// with one give an error, rather than leaving the service to send its requests to AWS.
+func resolveEndpoints(endpoints map[string]string) (map[string]string, error) {
+	known := make(map[string]bool)
+	for _, id := range clientEndpointsIDs {
+		known[id] = true
+	}
+
+	resolved := make(map[string]string)
+	for key, endpoint := range endpoints {
+		id := key
+		if !known[key] {
+			c, _ := getCollector(key)
+			ec, ok := c.(EndpointsCollector)
+			if !ok || ec.EndpointsID() == "" {
+				return nil, newErrInvalidEndpoint(key)
+			}
+			id = ec.EndpointsID()
+		}
+
+		if other, ok := resolved[id]; ok && other != endpoint {
+			return nil, fmt.Errorf("%w: conflicting endpoints for %s", ErrInvalidEndpoint, id)
+		}
+		resolved[id] = endpoint
+	}
+
+	return resolved, nil
+}
+
+// endpoint returns the URL overriding the AWS endpoint for the given service endpoint ID, if any
+func (c DefaultClients) endpoint(endpointsID string) string {
+	if endpoint, ok := c.endpoints[endpointsID]; ok {
+		return endpoint
+	}
+
+	return c.endpointURL
+}
+
+// stsConfigProvider returns the session used to assume roles, sending requests to any overridden STS endpoint
+func (c DefaultClients) stsConfigProvider() client.ConfigProvider {
+	endpoint := c.endpoint(sts.EndpointsID)
+	if endpoint == "" {
+		return c.sess
+	}
+
+	return c.sess.Copy(aws.NewConfig().WithEndpoint(endpoint))
 }
@@ -1,25 +1,49 @@
 package awsdata_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
 	"github.com/aws/aws-sdk-go/service/codecommit/codecommitiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
 	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
 	"github.com/aws/aws-sdk-go/service/elasticsearchservice/elasticsearchserviceiface"
+	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/fsx/fsxiface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
 )
 
 var testError = errors.New("test aws error")
@@ -31,13 +55,17 @@ type TestClients struct {
 	EC2                  ec2iface.EC2API
 	ECR                  ecriface.ECRAPI
 	ECS                  ecsiface.ECSAPI
+	EFS                  efsiface.EFSAPI
+	EKS                  eksiface.EKSAPI
 	ElastiCache          elasticacheiface.ElastiCacheAPI
 	ElasticsearchService elasticsearchserviceiface.ElasticsearchServiceAPI
 	ELB                  elbiface.ELBAPI
 	ELBV2                elbv2iface.ELBV2API
+	FSx                  fsxiface.FSxAPI
 	IAM                  iamiface.IAMAPI
 	KMS                  kmsiface.KMSAPI
 	Lambda               lambdaiface.LambdaAPI
+	Organizations        organizationsiface.OrganizationsAPI
 	RDS                  rdsiface.RDSAPI
 	Route53              route53iface.Route53API
 	S3                   s3iface.S3API
@@ -68,6 +96,14 @@ func (c TestClients) GetECSClient(region string) ecsiface.ECSAPI {
 	return c.ECS
 }
 
+func (c TestClients) GetEFSClient(region string) efsiface.EFSAPI {
+	return c.EFS
+}
+
+func (c TestClients) GetEKSClient(region string) eksiface.EKSAPI {
+	return c.EKS
+}
+
 func (c TestClients) GetElastiCacheClient(region string) elasticacheiface.ElastiCacheAPI {
 	return c.ElastiCache
 }
@@ -84,6 +120,10 @@ func (c TestClients) GetELBV2Client(region string) elbv2iface.ELBV2API {
 	return c.ELBV2
 }
 
+func (c TestClients) GetFSxClient(region string) fsxiface.FSxAPI {
+	return c.FSx
+}
+
 func (c TestClients) GetIAMClient(region string) iamiface.IAMAPI {
 	return c.IAM
 }
@@ -96,6 +136,10 @@ func (c TestClients) GetLambdaClient(region string) lambdaiface.LambdaAPI {
 	return c.Lambda
 }
 
+func (c TestClients) GetOrganizationsClient(region string) organizationsiface.OrganizationsAPI {
+	return c.Organizations
+}
+
 func (c TestClients) GetRDSClient(region string) rdsiface.RDSAPI {
 	return c.RDS
 }
@@ -111,3 +155,168 @@ func (c TestClients) GetS3Client(region string) s3iface.S3API {
 func (c TestClients) GetSQSClient(region string) sqsiface.SQSAPI {
 	return c.SQS
 }
+
+func TestDefaultClientsRetryFailedRequests(t *testing.T) {
+	c, err := NewDefaultClients(SessionOptions{MaxRetries: aws.Int(3)})
+	require.NoError(t, err)
+
+	ec2Svc := c.GetEC2Client(DefaultRegion).(*ec2.EC2)
+
+	require.Equal(t, 3, ec2Svc.Retryer.MaxRetries())
+	require.True(t, ec2Svc.Retryer.ShouldRetry(&request.Request{Error: errors.New("connection reset"), HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError}}))
+}
+
+func TestDefaultClientsLeaveThrottledRequestsToLoad(t *testing.T) {
+	c, err := NewDefaultClients(SessionOptions{MaxRetries: aws.Int(3)})
+	require.NoError(t, err)
+
+	ec2Svc := c.GetEC2Client(DefaultRegion).(*ec2.EC2)
+
+	require.False(t, ec2Svc.Retryer.ShouldRetry(&request.Request{Error: testThrottlingError}))
+}
+
+func TestDefaultClientsCanDisableRetries(t *testing.T) {
+	c, err := NewDefaultClients(SessionOptions{MaxRetries: aws.Int(0)})
+	require.NoError(t, err)
+
+	ec2Svc := c.GetEC2Client(DefaultRegion).(*ec2.EC2)
+
+	require.Equal(t, 0, ec2Svc.Retryer.MaxRetries())
+}
+
+func TestDefaultClientsUseDefaultMaxRetries(t *testing.T) {
+	c, err := NewDefaultClients(SessionOptions{})
+	require.NoError(t, err)
+
+	ec2Svc := c.GetEC2Client(DefaultRegion).(*ec2.EC2)
+
+	require.Equal(t, DefaultMaxRetries, ec2Svc.Retryer.MaxRetries())
+}
+
+func TestNewDefaultClientsReturnsErrorForMissingCABundle(t *testing.T) {
+	_, err := NewDefaultClients(SessionOptions{CABundle: filepath.Join(t.TempDir(), "ca.pem")})
+
+	require.Error(t, err)
+}
+
+func TestDefaultClientsCanUseCustomEndpoint(t *testing.T) {
+	os.Setenv("AWS_ACCESS_KEY_ID", "test")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	defer os.Unsetenv("AWS_ACCESS_KEY_ID")
+	defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+
+	// A stand-in for DynamoDB which has a single table
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "DynamoDB_20120810.ListTables":
+			fmt.Fprintf(w, `{"TableNames":[%q]}`, testDynamoDBTableRows[0].UniqueAssetIdentifier)
+		case "DynamoDB_20120810.DescribeTable":
+			fmt.Fprintf(w, `{"Table":{"TableName":%q,"TableArn":%q,"TableSizeBytes":100}}`, testDynamoDBTableRows[0].UniqueAssetIdentifier, testDynamoDBTableRows[0].SerialAssetTagNumber)
+		case "DynamoDB_20120810.ListTagsOfResource":
+			fmt.Fprint(w, `{"Tags":[{"Key":"Owner","Value":"test owner"}]}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewDefaultClients(SessionOptions{EndpointURL: server.URL})
+	require.NoError(t, err)
+
+	d := New(logrus.New(), c)
+
+	var rows []inventory.Row
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	require.True(t, report.Complete())
+	require.Equal(t, testDynamoDBTableRows[:1], rows)
+}
+
+func TestDefaultClientsCanUseCustomEndpointForService(t *testing.T) {
+	c, err := NewDefaultClients(SessionOptions{
+		EndpointURL: "http://localhost:4566",
+		Endpoints: map[string]string{
+			s3.EndpointsID: "http://localhost:9000",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "http://localhost:4566", c.GetEC2Client(DefaultRegion).(*ec2.EC2).Endpoint)
+	require.Equal(t, "http://localhost:9000", c.GetS3Client(DefaultRegion).(*s3.S3).Endpoint)
+}
+
+func TestDefaultClientsCanUseCustomEndpointForServiceName(t *testing.T) {
+	c, err := NewDefaultClients(SessionOptions{
+		Endpoints: map[string]string{
+			ServiceEFS: "http://localhost:9000",
+			ServiceELB: "http://localhost:9001",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "http://localhost:9000", c.GetEFSClient(DefaultRegion).(*efs.EFS).Endpoint)
+	require.Equal(t, "http://localhost:9001", c.GetELBClient(DefaultRegion).(*elb.ELB).Endpoint)
+	require.Equal(t, "http://localhost:9001", c.GetELBV2Client(DefaultRegion).(*elbv2.ELBV2).Endpoint)
+}
+
+func TestNewDefaultClientsReturnsErrorForUnknownEndpoint(t *testing.T) {
+	_, err := NewDefaultClients(SessionOptions{
+		Endpoints: map[string]string{
+			"invalid-service": "http://localhost:9000",
+		},
+	})
+
+	require.True(t, errors.Is(err, ErrInvalidEndpoint))
+	require.EqualError(t, err, "invalid endpoint: unknown service invalid-service")
+}
+
+func TestNewDefaultClientsReturnsErrorForConflictingEndpoints(t *testing.T) {
+	_, err := NewDefaultClients(SessionOptions{
+		Endpoints: map[string]string{
+			ServiceELB:   "http://localhost:9000",
+			ServiceELBV2: "http://localhost:9001",
+		},
+	})
+
+	require.True(t, errors.Is(err, ErrInvalidEndpoint))
+}
+
+func TestDefaultClientsPassExternalIDWhenAssumingRoles(t *testing.T) {
+	os.Setenv("AWS_ACCESS_KEY_ID", "test")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	os.Setenv("AWS_REGION", DefaultRegion)
+	defer os.Unsetenv("AWS_ACCESS_KEY_ID")
+	defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+	defer os.Unsetenv("AWS_REGION")
+
+	// A stand-in for STS which records the external ID of each role assumed
+	var externalIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		externalIDs = append(externalIDs, r.PostForm.Get("ExternalId"))
+
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, `<AssumeRoleResponse><AssumeRoleResult><Credentials>`+
+			`<AccessKeyId>assumed</AccessKeyId><SecretAccessKey>assumed</SecretAccessKey><SessionToken>assumed</SessionToken>`+
+			`<Expiration>2099-01-01T00:00:00Z</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`)
+	}))
+	defer server.Close()
+
+	c, err := NewDefaultClients(SessionOptions{
+		ExternalID: "test-external-id",
+		Endpoints:  map[string]string{"sts": server.URL},
+	})
+	require.NoError(t, err)
+
+	dynamodbSvc := c.AssumeRole("arn:aws:iam::123456789012:role/test").GetDynamoDBClient(DefaultRegion).(*dynamodb.DynamoDB)
+
+	creds, err := dynamodbSvc.Config.Credentials.Get()
+	require.NoError(t, err)
+	require.Equal(t, "assumed", creds.AccessKeyID)
+	require.Equal(t, []string{"test-external-id"}, externalIDs)
+}
@@ -1,6 +1,8 @@
 package awsdata
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -17,13 +19,17 @@ const (
 	ServiceCloudFront string = "cloudfront"
 )
 
-func (d *AWSData) loadCloudFrontDistributions() {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewCollector(ServiceCloudFront, true, func(ctx context.Context, d *AWSData, region string) {
+		d.loadCloudFrontDistributions(ctx, region)
+	}))
+}
 
-	cloudfrontSvc := d.clients.GetCloudFrontClient(DefaultRegion)
+func (d *AWSData) loadCloudFrontDistributions(ctx context.Context, region string) {
+	cloudfrontSvc := d.clients.GetCloudFrontClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
-		"region":  "global",
+		"region":  RegionGlobal,
 		"service": ServiceCloudFront,
 	})
 
@@ -33,10 +39,13 @@ func (d *AWSData) loadCloudFrontDistributions() {
 	done := false
 	params := &cloudfront.ListDistributionsInput{}
 	for !done {
-		out, err := cloudfrontSvc.ListDistributions(params)
-
+		var out *cloudfront.ListDistributionsOutput
+		err := d.retry(ctx, ServiceCloudFront, func() (err error) {
+			out, err = cloudfrontSvc.ListDistributionsWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to list distributions: %s", err)
+			d.AddError(ServiceCloudFront, RegionGlobal, fmt.Errorf("failed to list distributions: %w", err))
 			return
 		}
 
@@ -63,7 +72,21 @@ func (d *AWSData) loadCloudFrontDistributions() {
 			origins = append(origins, aws.StringValue(origin.DomainName))
 		}
 
-		d.rows <- inventory.Row{
+		var tags map[string]string
+		var outTags *cloudfront.ListTagsForResourceOutput
+		err := d.retry(ctx, ServiceCloudFront, func() (err error) {
+			outTags, err = cloudfrontSvc.ListTagsForResourceWithContext(ctx, &cloudfront.ListTagsForResourceInput{
+				Resource: dist.ARN,
+			})
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceCloudFront, RegionGlobal, fmt.Errorf("failed to list tags for %s: %w", aws.StringValue(dist.Id), err))
+		} else if outTags.Tags != nil {
+			tags = tagMap(len(outTags.Tags.Items), func(i int) (*string, *string) { return outTags.Tags.Items[i].Key, outTags.Tags.Items[i].Value })
+		}
+
+		d.AddRow(ServiceCloudFront, RegionGlobal, inventory.Row{
 			UniqueAssetIdentifier:     aws.StringValue(dist.Id),
 			Virtual:                   true,
 			Public:                    true,
@@ -72,7 +95,8 @@ func (d *AWSData) loadCloudFrontDistributions() {
 			AssetType:                 AssetTypeCloudFrontDistribution,
 			Function:                  aws.StringValue(dist.Comment),
 			SerialAssetTagNumber:      aws.StringValue(dist.ARN),
-		}
+			Tags:                      tags,
+		})
 	}
 
 	log.Info("finished processing data")
@@ -1,9 +1,11 @@
 package awsdata_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
 	"github.com/sirupsen/logrus"
@@ -24,6 +26,9 @@ var testCloudFrontDistributionRows = []inventory.Row{
 		AssetType:                 "CloudFront Distribution",
 		Function:                  "Test distribution 1",
 		SerialAssetTagNumber:      "arn:aws:cloudfront::123456789012:distribution/EDFDVBD632BHDS5",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier:     "EMLARXS9EXAMPLE",
@@ -125,7 +130,7 @@ type CloudFrontMock struct {
 	cloudfrontiface.CloudFrontAPI
 }
 
-func (e CloudFrontMock) ListDistributions(cfg *cloudfront.ListDistributionsInput) (*cloudfront.ListDistributionsOutput, error) {
+func (e CloudFrontMock) ListDistributionsWithContext(ctx aws.Context, cfg *cloudfront.ListDistributionsInput, opts ...request.Option) (*cloudfront.ListDistributionsOutput, error) {
 	if cfg.Marker == nil {
 		return testCloudFrontListDistributionsOutputPage1, nil
 	}
@@ -133,11 +138,28 @@ func (e CloudFrontMock) ListDistributions(cfg *cloudfront.ListDistributionsInput
 	return testCloudFrontListDistributionsOutputPage2, nil
 }
 
+func (e CloudFrontMock) ListTagsForResourceWithContext(ctx aws.Context, cfg *cloudfront.ListTagsForResourceInput, opts ...request.Option) (*cloudfront.ListTagsForResourceOutput, error) {
+	if aws.StringValue(cfg.Resource) != testCloudFrontDistributionRows[0].SerialAssetTagNumber {
+		return &cloudfront.ListTagsForResourceOutput{Tags: &cloudfront.Tags{}}, nil
+	}
+
+	return &cloudfront.ListTagsForResourceOutput{
+		Tags: &cloudfront.Tags{
+			Items: []*cloudfront.Tag{
+				{
+					Key:   aws.String("Owner"),
+					Value: aws.String("test owner"),
+				},
+			},
+		},
+	}, nil
+}
+
 type CloudFrontErrorMock struct {
 	cloudfrontiface.CloudFrontAPI
 }
 
-func (e CloudFrontErrorMock) ListDistributions(cfg *cloudfront.ListDistributionsInput) (*cloudfront.ListDistributionsOutput, error) {
+func (e CloudFrontErrorMock) ListDistributionsWithContext(ctx aws.Context, cfg *cloudfront.ListDistributionsInput, opts ...request.Option) (*cloudfront.ListDistributionsOutput, error) {
 	return &cloudfront.ListDistributionsOutput{}, testError
 }
 
@@ -146,7 +168,7 @@ func TestCanLoadCloudFrontDistributions(t *testing.T) {
 	d := New(logrus.New(), TestClients{CloudFront: CloudFrontMock{}})
 
 	var count int
-	d.Load([]string{}, []string{ServiceCloudFront}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{}, []string{ServiceCloudFront}, func(row inventory.Row) error {
 		require.Equal(t, testCloudFrontDistributionRows[count], row)
 		count++
 		return nil
@@ -159,7 +181,7 @@ func TestLoadCloudFrontDistributionsLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{CloudFront: CloudFrontErrorMock{}})
 
-	d.Load([]string{}, []string{ServiceCloudFront}, nil)
+	d.Load(context.Background(), []string{}, []string{ServiceCloudFront}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
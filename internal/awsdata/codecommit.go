@@ -1,6 +1,7 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -17,9 +18,13 @@ const (
 	ServiceCodeCommit string = "codecommit"
 )
 
-func (d *AWSData) loadCodeCommitRepositories(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceCodeCommit, codecommit.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadCodeCommitRepositories(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadCodeCommitRepositories(ctx context.Context, region string) {
 	codecommitSvc := d.clients.GetCodeCommitClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -33,10 +38,13 @@ func (d *AWSData) loadCodeCommitRepositories(region string) {
 	done := false
 	params := &codecommit.ListRepositoriesInput{}
 	for !done {
-		out, err := codecommitSvc.ListRepositories(params)
-
+		var out *codecommit.ListRepositoriesOutput
+		err := d.retry(ctx, ServiceCodeCommit, func() (err error) {
+			out, err = codecommitSvc.ListRepositoriesWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to list repositories: %s", err)
+			d.AddError(ServiceCodeCommit, region, fmt.Errorf("failed to list repositories: %w", err))
 			return
 		}
 
@@ -59,16 +67,34 @@ func (d *AWSData) loadCodeCommitRepositories(region string) {
 	}
 
 	// TODO: API call can only handle 100 repository names at a time
-	out, err := codecommitSvc.BatchGetRepositories(&codecommit.BatchGetRepositoriesInput{
-		RepositoryNames: aws.StringSlice(repositories),
+	var out *codecommit.BatchGetRepositoriesOutput
+	err := d.retry(ctx, ServiceCodeCommit, func() (err error) {
+		out, err = codecommitSvc.BatchGetRepositoriesWithContext(ctx, &codecommit.BatchGetRepositoriesInput{
+			RepositoryNames: aws.StringSlice(repositories),
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to get repositories: %s", err)
+		d.AddError(ServiceCodeCommit, region, fmt.Errorf("failed to get repositories: %w", err))
 		return
 	}
 
 	for _, r := range out.Repositories {
-		d.rows <- inventory.Row{
+		var tags map[string]string
+		var outTags *codecommit.ListTagsForResourceOutput
+		err := d.retry(ctx, ServiceCodeCommit, func() (err error) {
+			outTags, err = codecommitSvc.ListTagsForResourceWithContext(ctx, &codecommit.ListTagsForResourceInput{
+				ResourceArn: r.Arn,
+			})
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceCodeCommit, region, fmt.Errorf("failed to list tags for %s: %w", aws.StringValue(r.RepositoryName), err))
+		} else {
+			tags = stringMapTags(outTags.Tags)
+		}
+
+		d.AddRow(ServiceCodeCommit, region, inventory.Row{
 			UniqueAssetIdentifier: fmt.Sprintf("%s-%s", aws.StringValue(r.RepositoryName), aws.StringValue(r.RepositoryId)),
 			Virtual:               true,
 			DNSNameOrURL:          aws.StringValue(r.CloneUrlHttp),
@@ -76,7 +102,8 @@ func (d *AWSData) loadCodeCommitRepositories(region string) {
 			AssetType:             AssetTypeCodeCommitRepository,
 			SerialAssetTagNumber:  aws.StringValue(r.Arn),
 			Function:              aws.StringValue(r.RepositoryDescription),
-		}
+			Tags:                  tags,
+		})
 	}
 
 	log.Info("finished processing data")
@@ -1,9 +1,11 @@
 package awsdata_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/codecommit"
 	"github.com/aws/aws-sdk-go/service/codecommit/codecommitiface"
 	"github.com/sirupsen/logrus"
@@ -23,6 +25,9 @@ var testCodeCommitRepositoryRows = []inventory.Row{
 		AssetType:             AssetTypeCodeCommitRepository,
 		SerialAssetTagNumber:  "arn:aws:codecommit:us-east-1:123456789012:TestRepository1",
 		Function:              "Test repository 1",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier: "TestRepository2-204e8c06-c92d-4c11-b151-e4903ef1c9b5",
@@ -96,7 +101,7 @@ type CodeCommitMock struct {
 	codecommitiface.CodeCommitAPI
 }
 
-func (e CodeCommitMock) ListRepositories(cfg *codecommit.ListRepositoriesInput) (*codecommit.ListRepositoriesOutput, error) {
+func (e CodeCommitMock) ListRepositoriesWithContext(ctx aws.Context, cfg *codecommit.ListRepositoriesInput, opts ...request.Option) (*codecommit.ListRepositoriesOutput, error) {
 	if cfg.NextToken == nil {
 		return testCodeCommitListRepositoriesOutputPage1, nil
 	}
@@ -104,19 +109,31 @@ func (e CodeCommitMock) ListRepositories(cfg *codecommit.ListRepositoriesInput)
 	return testCodeCommitListRepositoriesOutputPage2, nil
 }
 
-func (e CodeCommitMock) BatchGetRepositories(cfg *codecommit.BatchGetRepositoriesInput) (*codecommit.BatchGetRepositoriesOutput, error) {
+func (e CodeCommitMock) BatchGetRepositoriesWithContext(ctx aws.Context, cfg *codecommit.BatchGetRepositoriesInput, opts ...request.Option) (*codecommit.BatchGetRepositoriesOutput, error) {
 	return testCodeCommitBatchGetRepositoriesOutput, nil
 }
 
+func (e CodeCommitMock) ListTagsForResourceWithContext(ctx aws.Context, cfg *codecommit.ListTagsForResourceInput, opts ...request.Option) (*codecommit.ListTagsForResourceOutput, error) {
+	if aws.StringValue(cfg.ResourceArn) != testCodeCommitRepositoryRows[0].SerialAssetTagNumber {
+		return &codecommit.ListTagsForResourceOutput{}, nil
+	}
+
+	return &codecommit.ListTagsForResourceOutput{
+		Tags: map[string]*string{
+			"Owner": aws.String("test owner"),
+		},
+	}, nil
+}
+
 type CodeCommitErrorMock struct {
 	codecommitiface.CodeCommitAPI
 }
 
-func (e CodeCommitErrorMock) ListRepositories(cfg *codecommit.ListRepositoriesInput) (*codecommit.ListRepositoriesOutput, error) {
+func (e CodeCommitErrorMock) ListRepositoriesWithContext(ctx aws.Context, cfg *codecommit.ListRepositoriesInput, opts ...request.Option) (*codecommit.ListRepositoriesOutput, error) {
 	return &codecommit.ListRepositoriesOutput{}, testError
 }
 
-func (e CodeCommitErrorMock) BatchGetRepositories(cfg *codecommit.BatchGetRepositoriesInput) (*codecommit.BatchGetRepositoriesOutput, error) {
+func (e CodeCommitErrorMock) BatchGetRepositoriesWithContext(ctx aws.Context, cfg *codecommit.BatchGetRepositoriesInput, opts ...request.Option) (*codecommit.BatchGetRepositoriesOutput, error) {
 	return &codecommit.BatchGetRepositoriesOutput{}, testError
 }
 
@@ -125,7 +142,7 @@ func TestCanLoadCodeCommitRepositories(t *testing.T) {
 	d := New(logrus.New(), TestClients{CodeCommit: CodeCommitMock{}})
 
 	var count int
-	d.Load([]string{DefaultRegion}, []string{ServiceCodeCommit}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceCodeCommit}, func(row inventory.Row) error {
 		require.Equal(t, testCodeCommitRepositoryRows[count], row)
 		count++
 		return nil
@@ -138,7 +155,7 @@ func TestLoadCodeCommitRepositoriesLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{CodeCommit: CodeCommitErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceCodeCommit}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceCodeCommit}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
@@ -0,0 +1,133 @@
+package awsdata
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Collector loads the assets of a single AWS service into the inventory
+type Collector interface {
+	// Name returns the key for the service, as used with the services option
+	Name() string
+
+	// Global returns true when the service is not tied to a region
+	Global() bool
+
+	// Load fetches the assets of the service in the given region and adds them to the AWSData.
+	// Global services are given the DefaultRegion. Load should return early once the context is done.
+	Load(ctx context.Context, d *AWSData, region string)
+}
+
+// LoadFunc loads the assets of a service in the given region and adds them to the AWSData
+type LoadFunc func(ctx context.Context, d *AWSData, region string)
+
+// EndpointsCollector is a Collector which knows the ID of its service in the AWS endpoints metadata, letting
+// Load skip the regions where the service is not offered
+type EndpointsCollector interface {
+	Collector
+
+	// EndpointsID returns the ID of the service in the AWS endpoints metadata, e.g. codecommit.EndpointsID
+	EndpointsID() string
+}
+
+type collector struct {
+	name        string
+	endpointsID string
+	global      bool
+	load        LoadFunc
+}
+
+// NewCollector returns a Collector for the named service which loads its assets using the given function
+func NewCollector(name string, global bool, load LoadFunc) Collector {
+	return collector{
+		name:   name,
+		global: global,
+		load:   load,
+	}
+}
+
+// NewEndpointsCollector returns a Collector like NewCollector for a service with the given ID in the AWS endpoints
+// metadata, so it is only loaded in the regions where the service is offered
+func NewEndpointsCollector(name, endpointsID string, global bool, load LoadFunc) Collector {
+	return collector{
+		name:        name,
+		endpointsID: endpointsID,
+		global:      global,
+		load:        load,
+	}
+}
+
+func (c collector) Name() string {
+	return c.name
+}
+
+func (c collector) Global() bool {
+	return c.global
+}
+
+func (c collector) EndpointsID() string {
+	return c.endpointsID
+}
+
+func (c collector) Load(ctx context.Context, d *AWSData, region string) {
+	c.load(ctx, d, region)
+}
+
+var (
+	collectorsMu sync.RWMutex
+	collectors   = make(map[string]Collector)
+)
+
+// RegisterCollector makes a collector available to AWSData by its name.
+// It panics if the collector is nil or a collector with the same name is already registered.
+func RegisterCollector(c Collector) {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+
+	if c == nil {
+		panic("awsdata: RegisterCollector collector is nil")
+	}
+
+	if _, dup := collectors[c.Name()]; dup {
+		panic(fmt.Sprintf("awsdata: RegisterCollector called twice for service %s", c.Name()))
+	}
+
+	collectors[c.Name()] = c
+}
+
+// Collectors returns the registered collectors sorted by name
+func Collectors() []Collector {
+	collectorsMu.RLock()
+	defer collectorsMu.RUnlock()
+
+	var list []Collector
+	for _, c := range collectors {
+		list = append(list, c)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name() < list[j].Name()
+	})
+
+	return list
+}
+
+// Services returns the names of the registered collectors in alphabetical order
+func Services() []string {
+	var services []string
+	for _, c := range Collectors() {
+		services = append(services, c.Name())
+	}
+
+	return services
+}
+
+func getCollector(name string) (Collector, bool) {
+	collectorsMu.RLock()
+	defer collectorsMu.RUnlock()
+
+	c, ok := collectors[name]
+	return c, ok
+}
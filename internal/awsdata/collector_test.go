@@ -0,0 +1,85 @@
+package awsdata_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+const testServiceCustom = "test-custom"
+
+var testCustomRow = inventory.Row{
+	UniqueAssetIdentifier: "custom-1",
+	Virtual:               true,
+	Location:              DefaultRegion,
+	AssetType:             "Custom Asset",
+}
+
+func init() {
+	RegisterCollector(NewCollector(testServiceCustom, false, func(ctx context.Context, d *AWSData, region string) {
+		row := testCustomRow
+		row.Location = region
+		d.AddRow(testServiceCustom, region, row)
+	}))
+}
+
+// Tests
+func TestServicesIncludesRegisteredCollectors(t *testing.T) {
+	services := Services()
+
+	require.Contains(t, services, ServiceEC2)
+	require.Contains(t, services, ServiceIAM)
+	require.Contains(t, services, testServiceCustom)
+}
+
+func TestCollectorsReportWhetherTheyAreGlobal(t *testing.T) {
+	for _, c := range Collectors() {
+		switch c.Name() {
+		case ServiceCloudFront, ServiceIAM:
+			require.True(t, c.Global(), "expected %s to be global", c.Name())
+		case ServiceEC2, ServiceS3:
+			require.False(t, c.Global(), "expected %s to be regional", c.Name())
+		}
+	}
+}
+
+func TestRegionalCollectorsHaveEndpointsIDs(t *testing.T) {
+	for _, c := range Collectors() {
+		if c.Global() || c.Name() == testServiceCustom || c.Name() == testServiceBlocking {
+			continue
+		}
+
+		ec, ok := c.(EndpointsCollector)
+		require.True(t, ok, "expected %s to have an endpoints ID", c.Name())
+		require.NotEmpty(t, ec.EndpointsID(), "expected %s to have an endpoints ID", c.Name())
+	}
+}
+
+func TestRegisterCollectorPanicsOnDuplicate(t *testing.T) {
+	require.Panics(t, func() {
+		RegisterCollector(NewCollector(ServiceEC2, false, func(ctx context.Context, d *AWSData, region string) {}))
+	})
+}
+
+func TestRegisterCollectorPanicsOnNil(t *testing.T) {
+	require.Panics(t, func() {
+		RegisterCollector(nil)
+	})
+}
+
+func TestCanLoadCustomCollector(t *testing.T) {
+	d := New(logrus.New(), TestClients{})
+
+	var rows []inventory.Row
+	d.Load(context.Background(), []string{DefaultRegion}, []string{testServiceCustom}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	require.Equal(t, []inventory.Row{testCustomRow}, rows)
+}
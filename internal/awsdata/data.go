@@ -1,8 +1,10 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
@@ -19,29 +21,49 @@ var (
 	DefaultRegion = "us-east-1"
 )
 
+// result is a row or an error loaded for a service in a region
 type result struct {
-	Row inventory.Row
-	Err error
+	Service string
+	Region  string
+	Row     inventory.Row
+	Err     error
 }
 
 // AWSData is responsible for concurrently loading data from AWS and storing it based on the regions and services provided
 type AWSData struct {
 	clients       Clients
-	rows          chan inventory.Row
+	account       *Account
+	results       chan result
+	report        *Report
 	regions       []string
 	validRegions  []string
 	validServices []string
 	route53Cache  *route53cache.Cache
 	log           *logrus.Logger
 	wg            sync.WaitGroup
-}
 
-// New returns a new default AWSData
-func New(logger *logrus.Logger, clients Clients) *AWSData {
-	if clients == nil {
-		clients = DefaultClients{}
-	}
+	maxConcurrency     int
+	serviceConcurrency map[string]int
+	pool               *pool
+
+	allRegions bool
+
+	tagMapping       map[string]string
+	includeTags      map[string]string
+	excludeTags      map[string]string
+	includeResources []string
+	excludeResources []string
+	filters          filters
+
+	maxRetries  int
+	retryDelay  time.Duration
+	retries     map[string]int
+	retriesLock sync.Mutex
+}
 
+// New returns a new AWSData, configured with any options given.
+// When clients is nil, DefaultClients with the default session are created the first time they are needed.
+func New(logger *logrus.Logger, clients Clients, opts ...Option) *AWSData {
 	// List of valid AWS regions to gather data from
 	var regions []string
 	resolver := endpoints.DefaultResolver()
@@ -52,56 +74,99 @@ func New(logger *logrus.Logger, clients Clients) *AWSData {
 		}
 	}
 
-	// List of valid AWS services to gather data from
-	var services = []string{
-		ServiceCloudFront,
-		ServiceCodeCommit,
-		ServiceDynamoDB,
-		ServiceEBS,
-		ServiceEC2,
-		ServiceECR,
-		ServiceECS,
-		ServiceElastiCache,
-		ServiceElasticsearchService,
-		ServiceELB,
-		ServiceELBV2,
-		ServiceIAM,
-		ServiceKMS,
-		ServiceLambda,
-		ServiceRDS,
-		ServiceS3,
-		ServiceSQS,
-	}
-
-	return &AWSData{
-		clients:       clients,
-		validRegions:  regions,
-		validServices: services,
-		rows:          make(chan inventory.Row, 100),
-		log:           logger,
-		wg:            sync.WaitGroup{},
+	d := &AWSData{
+		clients:            clients,
+		validRegions:       regions,
+		validServices:      Services(),
+		results:            make(chan result, 100),
+		report:             newReport(),
+		log:                logger,
+		wg:                 sync.WaitGroup{},
+		serviceConcurrency: make(map[string]int),
+		tagMapping:         make(map[string]string),
+		includeTags:        make(map[string]string),
+		excludeTags:        make(map[string]string),
+		maxRetries:         DefaultMaxRetries,
+		retryDelay:         DefaultRetryDelay,
+		retries:            make(map[string]int),
 	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	d.pool = newPool(d.maxConcurrency, d.serviceConcurrency)
+	d.report.Account = d.account
+
+	return d
 }
 
-// Load concurrently the required data based on the regions and services provided
-func (d *AWSData) Load(regions, services []string, processRow ProcessRow) {
+// Load concurrently the required data based on the regions and services provided, returning a report of the rows
+// loaded and errors encountered for each service and region. With WithAllRegions, the regions given are replaced
+// by those enabled for the account. Regions where a service is not offered are skipped and listed in the report
+// as unavailable rather than as errors.
+// When the context is cancelled or times out, the services still loading stop early and the rows already
+// loaded are still processed before Load returns.
+func (d *AWSData) Load(ctx context.Context, regions, services []string, processRow ProcessRow) *Report {
 	if len(services) == 0 {
 		services = d.validServices
 	}
 
-	if len(regions) == 0 && hasRegionalServices(services) {
+	if len(regions) == 0 && hasRegionalServices(services) && !d.allRegions {
 		d.log.Error(ErrNoRegions)
-		return
+		d.report.addError("", "", ErrNoRegions)
+		return d.report
 	}
 
 	if err := d.validateRegions(regions); err != nil {
 		d.log.Error(err)
-		return
+		d.report.addError("", "", err)
+		return d.report
 	}
 
 	if err := d.validateServices(services); err != nil {
 		d.log.Error(err)
-		return
+		d.report.addError("", "", err)
+		return d.report
+	}
+
+	for service := range d.serviceConcurrency {
+		if err := d.validateServices([]string{service}); err != nil {
+			d.log.Error(err)
+			d.report.addError("", "", err)
+			return d.report
+		}
+	}
+
+	for field := range d.tagMapping {
+		if !stringInSlice(field, inventory.Fields()) {
+			err := fmt.Errorf("%w: unknown field %s", ErrInvalidTagMapping, field)
+			d.log.Error(err)
+			d.report.addError("", "", err)
+			return d.report
+		}
+	}
+
+	if err := d.compileFilters(); err != nil {
+		d.log.Error(err)
+		d.report.addError("", "", err)
+		return d.report
+	}
+
+	if err := d.initClients(); err != nil {
+		d.log.Error(err)
+		d.report.addError("", "", err)
+		return d.report
+	}
+
+	if d.allRegions && hasRegionalServices(services) {
+		enabled, err := d.EnabledRegions(ctx)
+		if err != nil {
+			d.log.Error(err)
+			d.report.addError("", "", err)
+			return d.report
+		}
+		regions = enabled
 	}
 
 	if processRow == nil {
@@ -116,140 +181,160 @@ func (d *AWSData) Load(regions, services []string, processRow ProcessRow) {
 	go d.startWorker(processRow, done)
 
 	if stringInSlice(ServiceEC2, services) {
-		d.loadRoute53Data()
-	}
-
-	// Global services
-	if stringInSlice(ServiceCloudFront, services) {
-		d.log.Debug("including CloudFront service")
-		d.wg.Add(1)
-		go d.loadCloudFrontDistributions()
+		if err := d.loadRoute53Data(ctx); err != nil {
+			d.AddError(ServiceEC2, RegionGlobal, fmt.Errorf("failed to load route53 records: %w", err))
+		}
 	}
 
-	if stringInSlice(ServiceIAM, services) {
-		d.log.Debug("including IAM service")
-		d.wg.Add(1)
-		go d.loadIAMUsers()
-	}
+	for _, service := range services {
+		c, _ := getCollector(service)
+		d.log.Debugf("including %s service", c.Name())
 
-	// Regional Services
-	for _, region := range regions {
-		if stringInSlice(ServiceCodeCommit, services) {
-			d.log.Debug("including CodeCommit service")
-			d.wg.Add(1)
-			go d.loadCodeCommitRepositories(region)
+		if c.Global() {
+			d.startCollector(ctx, c, DefaultRegion)
+			continue
 		}
 
-		if stringInSlice(ServiceDynamoDB, services) {
-			d.log.Debug("including DynamoDB service")
-			d.wg.Add(1)
-			go d.loadDynamoDBTables(region)
-		}
+		for _, region := range regions {
+			if !serviceAvailable(c, region) {
+				d.log.WithFields(logrus.Fields{
+					"region":  region,
+					"service": c.Name(),
+				}).Warning("service not available in region, skipping")
+				d.report.addUnavailable(c.Name(), region)
+				continue
+			}
 
-		if stringInSlice(ServiceEBS, services) {
-			d.log.Debug("including EBS service")
-			d.wg.Add(1)
-			go d.loadEBSVolumes(region)
+			d.startCollector(ctx, c, region)
 		}
+	}
 
-		if stringInSlice(ServiceEC2, services) {
-			d.log.Debug("including EC2 service")
-			d.wg.Add(1)
-			go d.loadEC2Instances(region)
-		}
+	d.wg.Wait()
+	close(d.results)
+	if err := ctx.Err(); err != nil {
+		d.log.Warningf("data loading cut short: %s", err)
+	} else {
+		d.log.Info("all data loaded")
+	}
 
-		if stringInSlice(ServiceECR, services) {
-			d.log.Debug("including ECR service")
-			d.wg.Add(1)
-			go d.loadECRImages(region)
-		}
+	<-done
+	d.log.Info("all rows processed")
 
-		if stringInSlice(ServiceECS, services) {
-			d.log.Debug("including ECS service")
-			d.wg.Add(1)
-			go d.loadECSContainers(region)
-		}
+	for service, n := range d.retries {
+		d.report.Retries[service] = n
+	}
 
-		if stringInSlice(ServiceElastiCache, services) {
-			d.log.Debug("including ElastiCache service")
-			d.wg.Add(1)
-			go d.loadElastiCacheNodes(region)
-		}
+	if err := ctx.Err(); err != nil {
+		d.report.addError("", "", fmt.Errorf("data loading cut short: %w", err))
+	}
 
-		if stringInSlice(ServiceElasticsearchService, services) {
-			d.log.Debug("including Elasticsearch service")
-			d.wg.Add(1)
-			go d.loadElasticsearchDomains(region)
-		}
+	return d.report
+}
 
-		if stringInSlice(ServiceELB, services) {
-			d.log.Debug("including ELB service")
-			d.wg.Add(1)
-			go d.loadELBs(region)
-		}
+// startCollector loads the collector's data in the given region in the background
+func (d *AWSData) startCollector(ctx context.Context, c Collector, region string) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
 
-		if stringInSlice(ServiceELBV2, services) {
-			d.log.Debug("including ELB v2 service")
-			d.wg.Add(1)
-			go d.loadELBV2s(region)
+		c.Load(ctx, d, region)
+
+		if c.Global() {
+			region = RegionGlobal
 		}
 
-		if stringInSlice(ServiceKMS, services) {
-			d.log.Debug("including KMS service")
-			d.wg.Add(1)
-			go d.loadKMSKeys(region)
+		if err := ctx.Err(); err != nil {
+			d.AddError(c.Name(), region, fmt.Errorf("loading cut short: %w", err))
 		}
+	}()
+}
 
-		if stringInSlice(ServiceLambda, services) {
-			d.log.Debug("including Lambda service")
-			d.wg.Add(1)
-			go d.loadLambdaFunctions(region)
+func (d *AWSData) startWorker(processRow ProcessRow, done chan bool) {
+	for res := range d.results {
+		if res.Err != nil {
+			d.report.addError(res.Service, res.Region, res.Err)
+			continue
 		}
 
-		if stringInSlice(ServiceRDS, services) {
-			d.log.Debug("including RDS service")
-			d.wg.Add(1)
-			go d.loadRDSInstances(region)
+		if !d.keepRow(res.Row) {
+			d.log.Debugf("filtering out %s: %s", res.Row.AssetType, res.Row.UniqueAssetIdentifier)
+			d.report.addFiltered(res.Service, res.Region)
+			continue
 		}
 
-		if stringInSlice(ServiceS3, services) {
-			d.log.Debug("including S3 service")
-			d.wg.Add(1)
-			go d.loadS3Buckets(region)
+		if d.account != nil {
+			res.Row.AccountID = d.account.ID
+			res.Row.AccountAlias = d.account.Alias
 		}
 
-		if stringInSlice(ServiceSQS, services) {
-			d.log.Debug("including SQS service")
-			d.wg.Add(1)
-			go d.loadSQSQueues(region)
+		d.log.Debugf("processing %s: %s", res.Row.AssetType, res.Row.UniqueAssetIdentifier)
+
+		if err := processRow(res.Row); err != nil {
+			d.log.Errorf("process row function failed: %s", err)
+			d.report.addError(res.Service, res.Region, fmt.Errorf("process row function failed: %w", err))
+			continue
 		}
+
+		d.report.addRow(res.Service, res.Region)
 	}
 
-	d.wg.Wait()
-	close(d.rows)
-	d.log.Info("all data loaded")
+	done <- true
+}
 
-	<-done
-	d.log.Info("all rows processed")
+// Clients returns the clients used to create AWS service clients, for use by collectors
+func (d *AWSData) Clients() Clients {
+	return d.clients
 }
 
-func (d *AWSData) startWorker(processRow ProcessRow, done chan bool) {
-	var blankRow inventory.Row
-	for {
-		row, ok := <-d.rows
-		if row == blankRow || !ok {
-			done <- true
-			return
-		}
+// Logger returns the logger used by AWSData, for use by collectors
+func (d *AWSData) Logger() *logrus.Logger {
+	return d.log
+}
 
-		d.log.Debugf("processing %s: %s", row.AssetType, row.UniqueAssetIdentifier)
+// AddRow sends a row loaded for the service and region to be processed, for use by collectors, after setting any
+// fields mapped from its tags.
+// Global services should use RegionGlobal as the region. It must only be called while the collector's Load method is running.
+func (d *AWSData) AddRow(service, region string, row inventory.Row) {
+	d.applyTagMapping(&row)
 
-		if err := processRow(row); err != nil {
-			d.log.Errorf("process row function failed: %s", err)
-		}
+	d.results <- result{
+		Service: service,
+		Region:  region,
+		Row:     row,
+	}
+}
+
+// AddError logs and records an error which stopped some or all of the data for the service and region from loading,
+// for use by collectors. It must only be called while the collector's Load method is running.
+func (d *AWSData) AddError(service, region string, err error) {
+	d.log.WithFields(logrus.Fields{
+		"region":  region,
+		"service": service,
+	}).Error(err)
+
+	d.results <- result{
+		Service: service,
+		Region:  region,
+		Err:     err,
 	}
 }
 
+// initClients creates the default clients when none were given to New
+func (d *AWSData) initClients() error {
+	if d.clients != nil {
+		return nil
+	}
+
+	c, err := NewDefaultClients(SessionOptions{MaxRetries: aws.Int(d.maxRetries)})
+	if err != nil {
+		return fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	d.clients = c
+
+	return nil
+}
+
 // PrintRegions lists all available AWS regions as used by the command line `print-regions` option
 func (d *AWSData) PrintRegions() {
 	for _, r := range d.validRegions {
@@ -257,16 +342,24 @@ func (d *AWSData) PrintRegions() {
 	}
 }
 
-func (d *AWSData) loadRoute53Data() {
+// loadRoute53Data fills the route53 cache used to find DNS names for EC2 instances.
+// The cache is always set, and is left empty when the records fail to load.
+func (d *AWSData) loadRoute53Data(ctx context.Context) error {
+	d.route53Cache = route53cache.New(nil)
+
 	route53Svc := d.clients.GetRoute53Client(DefaultRegion)
 	d.log.Info("loading hosted zones")
 	var zones []*route53.HostedZone
 	done := false
 	params := &route53.ListHostedZonesInput{}
 	for !done {
-		out, err := route53Svc.ListHostedZones(params)
+		var out *route53.ListHostedZonesOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = route53Svc.ListHostedZonesWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			d.log.Fatal(err)
+			return err
 		}
 
 		zones = append(zones, out.HostedZones...)
@@ -281,12 +374,15 @@ func (d *AWSData) loadRoute53Data() {
 	d.log.Infof("found %d hosted zones", len(zones))
 
 	var sets []*route53.ResourceRecordSet
+	var errs []error
 
 	var lock sync.Mutex
 	var wg sync.WaitGroup
 	for _, z := range zones {
 		wg.Add(1)
 		go func(route53Svc route53iface.Route53API, zone *route53.HostedZone) {
+			defer wg.Done()
+
 			d.log.Infof("loading route53 records for hosted zone %s (%s)", aws.StringValue(zone.Name), aws.StringValue(zone.Id))
 
 			done := false
@@ -294,9 +390,16 @@ func (d *AWSData) loadRoute53Data() {
 				HostedZoneId: zone.Id,
 			}
 			for !done {
-				out, err := route53Svc.ListResourceRecordSets(params)
+				var out *route53.ListResourceRecordSetsOutput
+				err := d.retry(ctx, ServiceEC2, func() (err error) {
+					out, err = route53Svc.ListResourceRecordSetsWithContext(ctx, params)
+					return
+				})
 				if err != nil {
-					d.log.Fatal(err)
+					lock.Lock()
+					errs = append(errs, err)
+					lock.Unlock()
+					return
 				}
 
 				d.log.Infof("found %d records in hosted zone %s (%s)", len(out.ResourceRecordSets), aws.StringValue(zone.Name), aws.StringValue(zone.Id))
@@ -313,13 +416,18 @@ func (d *AWSData) loadRoute53Data() {
 					done = true
 				}
 			}
-			wg.Done()
 		}(route53Svc, z)
 	}
 
 	wg.Wait()
 
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
 	d.route53Cache = route53cache.New(sets)
+
+	return nil
 }
 
 func stringInSlice(needle string, haystack []string) bool {
@@ -355,13 +463,8 @@ func humanReadableBytes(b int64) string {
 }
 
 func hasRegionalServices(services []string) bool {
-	var globalServices = []string{
-		ServiceCloudFront,
-		ServiceIAM,
-	}
-
 	for _, service := range services {
-		if !stringInSlice(service, globalServices) {
+		if c, ok := getCollector(service); ok && !c.Global() {
 			return true
 		}
 	}
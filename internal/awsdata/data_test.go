@@ -1,19 +1,33 @@
 package awsdata_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
-	. "github.com/manywho/awsinventory/internal/awsdata"
 	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
 )
 
+const testServiceBlocking = "test-blocking"
+
+func init() {
+	RegisterCollector(NewCollector(testServiceBlocking, false, func(ctx context.Context, d *AWSData, region string) {
+		d.AddRow(testServiceBlocking, region, testCustomRow)
+		<-ctx.Done()
+	}))
+}
+
 func TestLoadExitsEarlyWhenRegionsIsEmptyAndRegionalServicesAreIncluded(t *testing.T) {
 	logger, hook := logrustest.NewNullLogger()
 
 	d := New(logger, TestClients{})
 
-	d.Load([]string{}, []string{ServiceEC2}, nil)
+	d.Load(context.Background(), []string{}, []string{ServiceEC2}, nil)
 
 	assertErrorWasLogged(t, hook.Entries, ErrNoRegions)
 }
@@ -23,7 +37,7 @@ func TestLoadCatchesInvalidRegion(t *testing.T) {
 
 	d := New(logger, TestClients{})
 
-	d.Load([]string{"test-region"}, []string{}, nil)
+	d.Load(context.Background(), []string{"test-region"}, []string{}, nil)
 
 	assertErrorWasLogged(t, hook.Entries, errors.New("invalid region: test-region"))
 }
@@ -33,7 +47,25 @@ func TestLoadCatchesInvalidService(t *testing.T) {
 
 	d := New(logger, TestClients{})
 
-	d.Load([]string{DefaultRegion}, []string{"invalid-service"}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{"invalid-service"}, nil)
 
 	assertErrorWasLogged(t, hook.Entries, errors.New("invalid service: invalid-service"))
 }
+
+func TestLoadStopsWhenContextIsCancelled(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	var rows []inventory.Row
+	d.Load(ctx, []string{DefaultRegion}, []string{testServiceBlocking}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	require.Equal(t, []inventory.Row{testCustomRow}, rows, "expected rows loaded before cancellation to be processed")
+	assertErrorWasLogged(t, hook.Entries, context.DeadlineExceeded)
+}
@@ -1,6 +1,9 @@
 package awsdata
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
@@ -16,9 +19,13 @@ const (
 	ServiceDynamoDB string = "dynamodb"
 )
 
-func (d *AWSData) loadDynamoDBTables(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceDynamoDB, dynamodb.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadDynamoDBTables(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadDynamoDBTables(ctx context.Context, region string) {
 	dynamodbSvc := d.clients.GetDynamoDBClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -32,10 +39,13 @@ func (d *AWSData) loadDynamoDBTables(region string) {
 	done := false
 	params := &dynamodb.ListTablesInput{}
 	for !done {
-		out, err := dynamodbSvc.ListTables(params)
-
+		var out *dynamodb.ListTablesOutput
+		err := d.retry(ctx, ServiceDynamoDB, func() (err error) {
+			out, err = dynamodbSvc.ListTablesWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to list tables: %s", err)
+			d.AddError(ServiceDynamoDB, region, fmt.Errorf("failed to list tables: %w", err))
 			return
 		}
 
@@ -52,24 +62,33 @@ func (d *AWSData) loadDynamoDBTables(region string) {
 
 	for _, t := range tables {
 		d.wg.Add(1)
-		go d.processDynamoDBTable(log, dynamodbSvc, t, region)
+		go d.processDynamoDBTable(ctx, log, dynamodbSvc, t, region)
 	}
 
 	log.Info("finished processing data")
 }
 
-func (d *AWSData) processDynamoDBTable(log *logrus.Entry, dynamodbSvc dynamodbiface.DynamoDBAPI, table *string, region string) {
+func (d *AWSData) processDynamoDBTable(ctx context.Context, log *logrus.Entry, dynamodbSvc dynamodbiface.DynamoDBAPI, table *string, region string) {
 	defer d.wg.Done()
 
-	out, err := dynamodbSvc.DescribeTable(&dynamodb.DescribeTableInput{
-		TableName: table,
+	var out *dynamodb.DescribeTableOutput
+	err := d.retry(ctx, ServiceDynamoDB, func() (err error) {
+		out, err = dynamodbSvc.DescribeTableWithContext(ctx, &dynamodb.DescribeTableInput{
+			TableName: table,
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to describe table %s: %s", aws.StringValue(table), err)
+		d.AddError(ServiceDynamoDB, region, fmt.Errorf("failed to describe table %s: %w", aws.StringValue(table), err))
 		return
 	}
 
-	d.rows <- inventory.Row{
+	tags, err := d.listDynamoDBTags(ctx, dynamodbSvc, out.Table.TableArn)
+	if err != nil {
+		d.AddError(ServiceDynamoDB, region, fmt.Errorf("failed to list tags for %s: %w", aws.StringValue(table), err))
+	}
+
+	d.AddRow(ServiceDynamoDB, region, inventory.Row{
 		UniqueAssetIdentifier:          aws.StringValue(out.Table.TableName),
 		Virtual:                        true,
 		Public:                         false,
@@ -79,5 +98,40 @@ func (d *AWSData) processDynamoDBTable(log *logrus.Entry, dynamodbSvc dynamodbif
 		SoftwareDatabaseNameAndVersion: "DynamoDB",
 		Comments:                       humanReadableBytes(aws.Int64Value(out.Table.TableSizeBytes)),
 		SerialAssetTagNumber:           aws.StringValue(out.Table.TableArn),
+		Tags:                           tags,
+	})
+}
+
+// listDynamoDBTags returns the tags of the DynamoDB resource as a map, or nil when it has none
+func (d *AWSData) listDynamoDBTags(ctx context.Context, dynamodbSvc dynamodbiface.DynamoDBAPI, arn *string) (map[string]string, error) {
+	var tags map[string]string
+	done := false
+	params := &dynamodb.ListTagsOfResourceInput{
+		ResourceArn: arn,
 	}
+	for !done {
+		var out *dynamodb.ListTagsOfResourceOutput
+		err := d.retry(ctx, ServiceDynamoDB, func() (err error) {
+			out, err = dynamodbSvc.ListTagsOfResourceWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range out.Tags {
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	return tags, nil
 }
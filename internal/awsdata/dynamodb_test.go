@@ -1,10 +1,12 @@
 package awsdata_test
 
 import (
+	"context"
 	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/sirupsen/logrus"
@@ -26,6 +28,9 @@ var testDynamoDBTableRows = []inventory.Row{
 		SoftwareDatabaseNameAndVersion: "DynamoDB",
 		Comments:                       "100 B",
 		SerialAssetTagNumber:           "arn:aws:dynamodb:us-east-1:123456789012:table/TestTable1",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier:          "TestTable2",
@@ -71,7 +76,7 @@ type DynamoDBMock struct {
 	dynamodbiface.DynamoDBAPI
 }
 
-func (e DynamoDBMock) ListTables(cfg *dynamodb.ListTablesInput) (*dynamodb.ListTablesOutput, error) {
+func (e DynamoDBMock) ListTablesWithContext(ctx aws.Context, cfg *dynamodb.ListTablesInput, opts ...request.Option) (*dynamodb.ListTablesOutput, error) {
 	if cfg.ExclusiveStartTableName == nil {
 		return testDynamoDBListTablesOutputPage1, nil
 	}
@@ -79,7 +84,7 @@ func (e DynamoDBMock) ListTables(cfg *dynamodb.ListTablesInput) (*dynamodb.ListT
 	return testDynamoDBListTablesOutputPage2, nil
 }
 
-func (e DynamoDBMock) DescribeTable(cfg *dynamodb.DescribeTableInput) (*dynamodb.DescribeTableOutput, error) {
+func (e DynamoDBMock) DescribeTableWithContext(ctx aws.Context, cfg *dynamodb.DescribeTableInput, opts ...request.Option) (*dynamodb.DescribeTableOutput, error) {
 	var row int
 	var bytes int64
 	switch aws.StringValue(cfg.TableName) {
@@ -102,15 +107,30 @@ func (e DynamoDBMock) DescribeTable(cfg *dynamodb.DescribeTableInput) (*dynamodb
 	}, nil
 }
 
+func (e DynamoDBMock) ListTagsOfResourceWithContext(ctx aws.Context, cfg *dynamodb.ListTagsOfResourceInput, opts ...request.Option) (*dynamodb.ListTagsOfResourceOutput, error) {
+	if aws.StringValue(cfg.ResourceArn) != testDynamoDBTableRows[0].SerialAssetTagNumber {
+		return &dynamodb.ListTagsOfResourceOutput{}, nil
+	}
+
+	return &dynamodb.ListTagsOfResourceOutput{
+		Tags: []*dynamodb.Tag{
+			{
+				Key:   aws.String("Owner"),
+				Value: aws.String("test owner"),
+			},
+		},
+	}, nil
+}
+
 type DynamoDBErrorMock struct {
 	dynamodbiface.DynamoDBAPI
 }
 
-func (e DynamoDBErrorMock) ListTables(cfg *dynamodb.ListTablesInput) (*dynamodb.ListTablesOutput, error) {
+func (e DynamoDBErrorMock) ListTablesWithContext(ctx aws.Context, cfg *dynamodb.ListTablesInput, opts ...request.Option) (*dynamodb.ListTablesOutput, error) {
 	return &dynamodb.ListTablesOutput{}, testError
 }
 
-func (e DynamoDBErrorMock) DescribeTable(cfg *dynamodb.DescribeTableInput) (*dynamodb.DescribeTableOutput, error) {
+func (e DynamoDBErrorMock) DescribeTableWithContext(ctx aws.Context, cfg *dynamodb.DescribeTableInput, opts ...request.Option) (*dynamodb.DescribeTableOutput, error) {
 	return &dynamodb.DescribeTableOutput{}, testError
 }
 
@@ -119,7 +139,7 @@ func TestCanLoadDynamoDBTables(t *testing.T) {
 	d := New(logrus.New(), TestClients{DynamoDB: DynamoDBMock{}})
 
 	var rows []inventory.Row
-	d.Load([]string{DefaultRegion}, []string{ServiceDynamoDB}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB}, func(row inventory.Row) error {
 		rows = append(rows, row)
 		return nil
 	})
@@ -140,7 +160,7 @@ func TestLoadDynamoDBTablesLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{DynamoDB: DynamoDBErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceDynamoDB}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
@@ -1,6 +1,7 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -18,9 +19,15 @@ const (
 	ServiceEBS string = "ebs"
 )
 
-func (d *AWSData) loadEBSVolumes(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceEBS, ec2.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadEBSVolumes(ctx, region)
+	}))
+}
 
+// loadEBSVolumes loads the EBS volumes in the region. They come from the EC2 API, so their requests are retried
+// and limited as EC2 requests.
+func (d *AWSData) loadEBSVolumes(ctx context.Context, region string) {
 	ec2Svc := d.clients.GetEC2Client(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -36,11 +43,15 @@ func (d *AWSData) loadEBSVolumes(region string) {
 	}
 
 	var accountID string
-	out, err := ec2Svc.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
-		MaxResults: aws.Int64(5),
+	var out *ec2.DescribeSecurityGroupsOutput
+	err := d.retry(ctx, ServiceEC2, func() (err error) {
+		out, err = ec2Svc.DescribeSecurityGroupsWithContext(ctx, &ec2.DescribeSecurityGroupsInput{
+			MaxResults: aws.Int64(5),
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to load account if from security groups: %s", err)
+		d.AddError(ServiceEBS, region, fmt.Errorf("failed to load account if from security groups: %w", err))
 		return
 	} else if len(out.SecurityGroups) > 0 {
 		accountID = aws.StringValue(out.SecurityGroups[0].OwnerId)
@@ -50,9 +61,13 @@ func (d *AWSData) loadEBSVolumes(region string) {
 	done := false
 	params := &ec2.DescribeVolumesInput{}
 	for !done {
-		out, err := ec2Svc.DescribeVolumes(params)
+		var out *ec2.DescribeVolumesOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeVolumesWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to describe volumes: %s", err)
+			d.AddError(ServiceEBS, region, fmt.Errorf("failed to describe volumes: %w", err))
 			return
 		}
 
@@ -75,7 +90,7 @@ func (d *AWSData) loadEBSVolumes(region string) {
 			}
 		}
 
-		d.rows <- inventory.Row{
+		d.AddRow(ServiceEBS, region, inventory.Row{
 			UniqueAssetIdentifier: aws.StringValue(v.VolumeId),
 			Virtual:               true,
 			Location:              region,
@@ -83,7 +98,8 @@ func (d *AWSData) loadEBSVolumes(region string) {
 			HardwareMakeModel:     fmt.Sprintf("%s (%dGB)", aws.StringValue(v.VolumeType), aws.Int64Value(v.Size)),
 			Function:              name,
 			SerialAssetTagNumber:  fmt.Sprintf("arn:%s:ec2:%s:%s:volume/%s", partition, region, accountID, aws.StringValue(v.VolumeId)),
-		}
+			Tags:                  ec2Tags(v.Tags),
+		})
 	}
 
 	log.Info("finished processing data")
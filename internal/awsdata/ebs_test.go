@@ -1,9 +1,11 @@
 package awsdata_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/sirupsen/logrus"
@@ -23,6 +25,10 @@ var testEBSVolumeRows = []inventory.Row{
 		HardwareMakeModel:     "gp2 (100GB)",
 		Function:              "test app 1",
 		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:012345678910:volume/vol-12345678",
+		Tags: map[string]string{
+			"Name":      "test app 1",
+			"extra tag": "testval",
+		},
 	},
 	{
 		UniqueAssetIdentifier: "vol-abcdefgh",
@@ -87,11 +93,11 @@ type EBSMock struct {
 	ec2iface.EC2API
 }
 
-func (e EBSMock) DescribeSecurityGroups(cfg *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+func (e EBSMock) DescribeSecurityGroupsWithContext(ctx aws.Context, cfg *ec2.DescribeSecurityGroupsInput, opts ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error) {
 	return testEC2DescribeSecurityGroupsOutput, nil
 }
 
-func (e EBSMock) DescribeVolumes(cfg *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
+func (e EBSMock) DescribeVolumesWithContext(ctx aws.Context, cfg *ec2.DescribeVolumesInput, opts ...request.Option) (*ec2.DescribeVolumesOutput, error) {
 	if cfg.NextToken == nil {
 		return testEBSDescribeVolumesOutputPage1, nil
 	}
@@ -103,11 +109,11 @@ type EBSErrorMock struct {
 	ec2iface.EC2API
 }
 
-func (e EBSErrorMock) DescribeSecurityGroups(cfg *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+func (e EBSErrorMock) DescribeSecurityGroupsWithContext(ctx aws.Context, cfg *ec2.DescribeSecurityGroupsInput, opts ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error) {
 	return &ec2.DescribeSecurityGroupsOutput{}, testError
 }
 
-func (e EBSErrorMock) DescribeVolumes(cfg *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
+func (e EBSErrorMock) DescribeVolumesWithContext(ctx aws.Context, cfg *ec2.DescribeVolumesInput, opts ...request.Option) (*ec2.DescribeVolumesOutput, error) {
 	return &ec2.DescribeVolumesOutput{}, testError
 }
 
@@ -116,7 +122,7 @@ func TestCanLoadEBSVolumes(t *testing.T) {
 	d := New(logrus.New(), TestClients{EC2: EBSMock{}})
 
 	var count int
-	d.Load([]string{DefaultRegion}, []string{ServiceEBS}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEBS}, func(row inventory.Row) error {
 		require.Equal(t, testEBSVolumeRows[count], row)
 		count++
 		return nil
@@ -129,7 +135,7 @@ func TestLoadEBSVolumesLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{EC2: EBSErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceEBS}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEBS}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
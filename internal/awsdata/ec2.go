@@ -1,6 +1,7 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -20,9 +21,13 @@ const (
 	ServiceEC2 string = "ec2"
 )
 
-func (d *AWSData) loadEC2Instances(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceEC2, ec2.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadEC2Instances(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadEC2Instances(ctx context.Context, region string) {
 	ec2Svc := d.clients.GetEC2Client(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -38,11 +43,15 @@ func (d *AWSData) loadEC2Instances(region string) {
 	}
 
 	var accountID string
-	out, err := ec2Svc.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
-		MaxResults: aws.Int64(5),
+	var out *ec2.DescribeSecurityGroupsOutput
+	err := d.retry(ctx, ServiceEC2, func() (err error) {
+		out, err = ec2Svc.DescribeSecurityGroupsWithContext(ctx, &ec2.DescribeSecurityGroupsInput{
+			MaxResults: aws.Int64(5),
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to load account id from security groups: %s", err)
+		d.AddError(ServiceEC2, region, fmt.Errorf("failed to load account id from security groups: %w", err))
 		return
 	} else if len(out.SecurityGroups) > 0 {
 		accountID = aws.StringValue(out.SecurityGroups[0].OwnerId)
@@ -63,9 +72,13 @@ func (d *AWSData) loadEC2Instances(region string) {
 		},
 	}
 	for !done {
-		out, err := ec2Svc.DescribeInstances(params)
+		var out *ec2.DescribeInstancesOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeInstancesWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to describe instances: %s", err)
+			d.AddError(ServiceEC2, region, fmt.Errorf("failed to describe instances: %w", err))
 			return
 		}
 
@@ -83,23 +96,16 @@ func (d *AWSData) loadEC2Instances(region string) {
 	for _, r := range reservations {
 		for _, i := range r.Instances {
 			d.wg.Add(1)
-			go d.processEC2Instance(log, ec2Svc, i, accountID, region, partition)
+			go d.processEC2Instance(ctx, log, ec2Svc, i, accountID, region, partition)
 		}
 	}
 
 	log.Info("finished processing data")
 }
 
-func (d *AWSData) processEC2Instance(log *logrus.Entry, ec2Svc ec2iface.EC2API, instance *ec2.Instance, accountID string, region string, partition string) {
+func (d *AWSData) processEC2Instance(ctx context.Context, log *logrus.Entry, ec2Svc ec2iface.EC2API, instance *ec2.Instance, accountID string, region string, partition string) {
 	defer d.wg.Done()
 
-	var name string
-	for _, tag := range instance.Tags {
-		if *tag.Key == "Name" {
-			name = aws.StringValue(tag.Value)
-		}
-	}
-
 	var public = false
 	var ips []string
 	var macAddresses []string
@@ -132,16 +138,20 @@ func (d *AWSData) processEC2Instance(log *logrus.Entry, ec2Svc ec2iface.EC2API,
 	}
 
 	var amiName string
-	images, err := ec2Svc.DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{
-		instance.ImageId,
-	}})
+	var images *ec2.DescribeImagesOutput
+	err := d.retry(ctx, ServiceEC2, func() (err error) {
+		images, err = ec2Svc.DescribeImagesWithContext(ctx, &ec2.DescribeImagesInput{ImageIds: []*string{
+			instance.ImageId,
+		}})
+		return
+	})
 	if err != nil {
 		log.Warningf("failed to load ami for %s: %s", aws.StringValue(instance.InstanceId), err)
 	} else if len(images.Images) > 0 {
 		amiName = aws.StringValue(images.Images[0].Name)
 	}
 
-	d.rows <- inventory.Row{
+	d.AddRow(ServiceEC2, region, inventory.Row{
 		UniqueAssetIdentifier:     aws.StringValue(instance.InstanceId),
 		IPv4orIPv6Address:         strings.Join(ips, "\n"),
 		Virtual:                   true,
@@ -153,8 +163,25 @@ func (d *AWSData) processEC2Instance(log *logrus.Entry, ec2Svc ec2iface.EC2API,
 		Location:                  region,
 		AssetType:                 AssetTypeEC2Instance,
 		HardwareMakeModel:         aws.StringValue(instance.InstanceType),
-		Function:                  name,
+		Function:                  ec2Name(instance.Tags),
 		SerialAssetTagNumber:      fmt.Sprintf("arn:%s:ec2:%s:%s:instance/%s", partition, region, accountID, aws.StringValue(instance.InstanceId)),
 		VLANNetworkID:             aws.StringValue(instance.VpcId),
+		Tags:                      ec2Tags(instance.Tags),
+	})
+}
+
+// ec2Tags returns the tags of an EC2 resource as a map, or nil when it has none
+func ec2Tags(tags []*ec2.Tag) map[string]string {
+	return tagMap(len(tags), func(i int) (*string, *string) { return tags[i].Key, tags[i].Value })
+}
+
+// ec2Name returns the value of the Name tag of an EC2 resource, or an empty string when it has none
+func ec2Name(tags []*ec2.Tag) string {
+	for _, t := range tags {
+		if aws.StringValue(t.Key) == "Name" {
+			return aws.StringValue(t.Value)
+		}
 	}
+
+	return ""
 }
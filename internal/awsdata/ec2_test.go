@@ -1,10 +1,12 @@
 package awsdata_test
 
 import (
+	"context"
 	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/route53"
@@ -33,6 +35,10 @@ var testEC2InstanceRows = []inventory.Row{
 		Function:                  "test app 1",
 		SerialAssetTagNumber:      "arn:aws:ec2:us-east-1:012345678910:instance/i-11111111",
 		VLANNetworkID:             "vpc-12345678",
+		Tags: map[string]string{
+			"Name":      "test app 1",
+			"extra tag": "testval",
+		},
 	},
 	{
 		UniqueAssetIdentifier:     "i-22222222",
@@ -48,6 +54,10 @@ var testEC2InstanceRows = []inventory.Row{
 		Function:                  "test app 2",
 		SerialAssetTagNumber:      "arn:aws:ec2:us-east-1:012345678910:instance/i-22222222",
 		VLANNetworkID:             "vpc-abcdefgh",
+		Tags: map[string]string{
+			"Name":      "test app 2",
+			"extra tag": "testval",
+		},
 	},
 	{
 		UniqueAssetIdentifier:     "i-33333333",
@@ -62,6 +72,10 @@ var testEC2InstanceRows = []inventory.Row{
 		Function:                  "test app 3",
 		SerialAssetTagNumber:      "arn:aws:ec2:us-east-1:012345678910:instance/i-33333333",
 		VLANNetworkID:             "vpc-a1b2c3d4",
+		Tags: map[string]string{
+			"Name":      "test app 3",
+			"extra tag": "testval",
+		},
 	},
 }
 
@@ -206,11 +220,11 @@ type EC2Mock struct {
 	ec2iface.EC2API
 }
 
-func (e EC2Mock) DescribeSecurityGroups(cfg *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+func (e EC2Mock) DescribeSecurityGroupsWithContext(ctx aws.Context, cfg *ec2.DescribeSecurityGroupsInput, opts ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error) {
 	return testEC2DescribeSecurityGroupsOutput, nil
 }
 
-func (e EC2Mock) DescribeInstances(cfg *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+func (e EC2Mock) DescribeInstancesWithContext(ctx aws.Context, cfg *ec2.DescribeInstancesInput, opts ...request.Option) (*ec2.DescribeInstancesOutput, error) {
 	if cfg.NextToken == nil {
 		return testEC2DescribeInstancesOutputPage1, nil
 	}
@@ -218,7 +232,7 @@ func (e EC2Mock) DescribeInstances(cfg *ec2.DescribeInstancesInput) (*ec2.Descri
 	return testEC2DescribeInstancesOutputPage2, nil
 }
 
-func (e EC2Mock) DescribeImages(cfg *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+func (e EC2Mock) DescribeImagesWithContext(ctx aws.Context, cfg *ec2.DescribeImagesInput, opts ...request.Option) (*ec2.DescribeImagesOutput, error) {
 	var name string
 	switch aws.StringValue(cfg.ImageIds[0]) {
 	case testEC2InstanceRows[0].BaselineConfigurationName:
@@ -241,7 +255,7 @@ type EC2Route53Mock struct {
 	route53iface.Route53API
 }
 
-func (e EC2Route53Mock) ListHostedZones(cfg *route53.ListHostedZonesInput) (*route53.ListHostedZonesOutput, error) {
+func (e EC2Route53Mock) ListHostedZonesWithContext(ctx aws.Context, cfg *route53.ListHostedZonesInput, opts ...request.Option) (*route53.ListHostedZonesOutput, error) {
 	if cfg.Marker == testEC2Route53HostedZonesOutput.HostedZones[0].Id {
 		return &route53.ListHostedZonesOutput{}, nil
 	}
@@ -249,7 +263,7 @@ func (e EC2Route53Mock) ListHostedZones(cfg *route53.ListHostedZonesInput) (*rou
 	return testEC2Route53HostedZonesOutput, nil
 }
 
-func (e EC2Route53Mock) ListResourceRecordSets(cfg *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+func (e EC2Route53Mock) ListResourceRecordSetsWithContext(ctx aws.Context, cfg *route53.ListResourceRecordSetsInput, opts ...request.Option) (*route53.ListResourceRecordSetsOutput, error) {
 	if cfg.StartRecordName == aws.String(testEC2InstanceRows[0].DNSNameOrURL) {
 		return &route53.ListResourceRecordSetsOutput{}, nil
 	}
@@ -261,15 +275,15 @@ type EC2ErrorMock struct {
 	ec2iface.EC2API
 }
 
-func (e EC2ErrorMock) DescribeSecurityGroups(cfg *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+func (e EC2ErrorMock) DescribeSecurityGroupsWithContext(ctx aws.Context, cfg *ec2.DescribeSecurityGroupsInput, opts ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error) {
 	return &ec2.DescribeSecurityGroupsOutput{}, testError
 }
 
-func (e EC2ErrorMock) DescribeInstances(cfg *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+func (e EC2ErrorMock) DescribeInstancesWithContext(ctx aws.Context, cfg *ec2.DescribeInstancesInput, opts ...request.Option) (*ec2.DescribeInstancesOutput, error) {
 	return &ec2.DescribeInstancesOutput{}, testError
 }
 
-func (e EC2ErrorMock) DescribeImages(cfg *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+func (e EC2ErrorMock) DescribeImagesWithContext(ctx aws.Context, cfg *ec2.DescribeImagesInput, opts ...request.Option) (*ec2.DescribeImagesOutput, error) {
 	return &ec2.DescribeImagesOutput{}, testError
 }
 
@@ -279,7 +293,7 @@ func TestCanLoadEC2Instances(t *testing.T) {
 
 	var rows []inventory.Row
 
-	d.Load([]string{DefaultRegion}, []string{ServiceEC2}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEC2}, func(row inventory.Row) error {
 		rows = append(rows, row)
 		return nil
 	})
@@ -300,7 +314,7 @@ func TestLoadEC2InstancesLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{EC2: EC2ErrorMock{}, Route53: EC2Route53Mock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceEC2}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEC2}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
@@ -1,6 +1,7 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -19,9 +20,13 @@ const (
 	ServiceECR string = "ecr"
 )
 
-func (d *AWSData) loadECRImages(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceECR, ecr.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadECRImages(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadECRImages(ctx context.Context, region string) {
 	ecrSvc := d.clients.GetECRClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -35,10 +40,13 @@ func (d *AWSData) loadECRImages(region string) {
 	done := false
 	params := &ecr.DescribeRepositoriesInput{}
 	for !done {
-		out, err := ecrSvc.DescribeRepositories(params)
-
+		var out *ecr.DescribeRepositoriesOutput
+		err := d.retry(ctx, ServiceECR, func() (err error) {
+			out, err = ecrSvc.DescribeRepositoriesWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to describe repositories: %s", err)
+			d.AddError(ServiceECR, region, fmt.Errorf("failed to describe repositories: %w", err))
 			return
 		}
 
@@ -55,13 +63,13 @@ func (d *AWSData) loadECRImages(region string) {
 
 	for _, r := range repositories {
 		d.wg.Add(1)
-		go d.processECRRepository(log, ecrSvc, r, region)
+		go d.processECRRepository(ctx, log, ecrSvc, r, region)
 	}
 
 	log.Info("finished processing data")
 }
 
-func (d *AWSData) processECRRepository(log *logrus.Entry, ecrSvc ecriface.ECRAPI, repository *ecr.Repository, region string) {
+func (d *AWSData) processECRRepository(ctx context.Context, log *logrus.Entry, ecrSvc ecriface.ECRAPI, repository *ecr.Repository, region string) {
 	defer d.wg.Done()
 
 	var images []*ecr.ImageDetail
@@ -70,10 +78,13 @@ func (d *AWSData) processECRRepository(log *logrus.Entry, ecrSvc ecriface.ECRAPI
 		RepositoryName: repository.RepositoryName,
 	}
 	for !done {
-		out, err := ecrSvc.DescribeImages(params)
-
+		var out *ecr.DescribeImagesOutput
+		err := d.retry(ctx, ServiceECR, func() (err error) {
+			out, err = ecrSvc.DescribeImagesWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to describe images: %s", err)
+			d.AddError(ServiceECR, region, fmt.Errorf("failed to describe images: %w", err))
 			return
 		}
 
@@ -86,8 +97,22 @@ func (d *AWSData) processECRRepository(log *logrus.Entry, ecrSvc ecriface.ECRAPI
 		}
 	}
 
+	var tags map[string]string
+	var outTags *ecr.ListTagsForResourceOutput
+	err := d.retry(ctx, ServiceECR, func() (err error) {
+		outTags, err = ecrSvc.ListTagsForResourceWithContext(ctx, &ecr.ListTagsForResourceInput{
+			ResourceArn: repository.RepositoryArn,
+		})
+		return
+	})
+	if err != nil {
+		d.AddError(ServiceECR, region, fmt.Errorf("failed to list tags for %s: %w", aws.StringValue(repository.RepositoryName), err))
+	} else {
+		tags = tagMap(len(outTags.Tags), func(i int) (*string, *string) { return outTags.Tags[i].Key, outTags.Tags[i].Value })
+	}
+
 	for _, i := range images {
-		d.rows <- inventory.Row{
+		d.AddRow(ServiceECR, region, inventory.Row{
 			UniqueAssetIdentifier: fmt.Sprintf("%s-%s", aws.StringValue(i.RepositoryName), aws.StringValue(i.ImageDigest)),
 			Virtual:               true,
 			Public:                false,
@@ -97,6 +122,7 @@ func (d *AWSData) processECRRepository(log *logrus.Entry, ecrSvc ecriface.ECRAPI
 			Function:              strings.Join(aws.StringValueSlice(i.ImageTags), ","),
 			Comments:              humanReadableBytes(aws.Int64Value(i.ImageSizeInBytes)),
 			SerialAssetTagNumber:  aws.StringValue(i.ImageDigest),
-		}
+			Tags:                  tags,
+		})
 	}
 }
@@ -1,10 +1,12 @@
 package awsdata_test
 
 import (
+	"context"
 	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/sirupsen/logrus"
@@ -26,6 +28,9 @@ var testECRImageRows = []inventory.Row{
 		Function:              "latest",
 		Comments:              "200.6 MB",
 		SerialAssetTagNumber:  "sha256:3fc4ccfe745870e2c0d99f71f30ff0656c8dedd41cc1d7d3d376b0dbe685e2f3",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier: "TestRepo1-sha256:7692c3ad3540bb803c020b3aee66cd8887123234ea0c6e7143c0add73ff431ed",
@@ -37,6 +42,9 @@ var testECRImageRows = []inventory.Row{
 		Function:              "previous,last",
 		Comments:              "800.3 MB",
 		SerialAssetTagNumber:  "sha256:7692c3ad3540bb803c020b3aee66cd8887123234ea0c6e7143c0add73ff431ed",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier: "TestRepo1-sha256:8b5b9db0c13db24256c829aa364aa90c6d2eba318b9232a4ab9313b954d3555f",
@@ -47,6 +55,9 @@ var testECRImageRows = []inventory.Row{
 		AssetType:             AssetTypeECRImage,
 		Comments:              "1.1 GB",
 		SerialAssetTagNumber:  "sha256:8b5b9db0c13db24256c829aa364aa90c6d2eba318b9232a4ab9313b954d3555f",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 }
 
@@ -100,7 +111,7 @@ type ECRMock struct {
 	ecriface.ECRAPI
 }
 
-func (e ECRMock) DescribeRepositories(cfg *ecr.DescribeRepositoriesInput) (*ecr.DescribeRepositoriesOutput, error) {
+func (e ECRMock) DescribeRepositoriesWithContext(ctx aws.Context, cfg *ecr.DescribeRepositoriesInput, opts ...request.Option) (*ecr.DescribeRepositoriesOutput, error) {
 	if cfg.NextToken == testECRDescribeRepositoriesOutput.NextToken {
 		return &ecr.DescribeRepositoriesOutput{}, nil
 	}
@@ -108,7 +119,7 @@ func (e ECRMock) DescribeRepositories(cfg *ecr.DescribeRepositoriesInput) (*ecr.
 	return testECRDescribeRepositoriesOutput, nil
 }
 
-func (e ECRMock) DescribeImages(cfg *ecr.DescribeImagesInput) (*ecr.DescribeImagesOutput, error) {
+func (e ECRMock) DescribeImagesWithContext(ctx aws.Context, cfg *ecr.DescribeImagesInput, opts ...request.Option) (*ecr.DescribeImagesOutput, error) {
 	if cfg.NextToken == nil {
 		return testECRDescribeImagesOutputPage1, nil
 	}
@@ -116,15 +127,26 @@ func (e ECRMock) DescribeImages(cfg *ecr.DescribeImagesInput) (*ecr.DescribeImag
 	return testECRDescribeImagesOutputPage2, nil
 }
 
+func (e ECRMock) ListTagsForResourceWithContext(ctx aws.Context, cfg *ecr.ListTagsForResourceInput, opts ...request.Option) (*ecr.ListTagsForResourceOutput, error) {
+	return &ecr.ListTagsForResourceOutput{
+		Tags: []*ecr.Tag{
+			{
+				Key:   aws.String("Owner"),
+				Value: aws.String("test owner"),
+			},
+		},
+	}, nil
+}
+
 type ECRErrorMock struct {
 	ecriface.ECRAPI
 }
 
-func (e ECRErrorMock) DescribeRepositories(cfg *ecr.DescribeRepositoriesInput) (*ecr.DescribeRepositoriesOutput, error) {
+func (e ECRErrorMock) DescribeRepositoriesWithContext(ctx aws.Context, cfg *ecr.DescribeRepositoriesInput, opts ...request.Option) (*ecr.DescribeRepositoriesOutput, error) {
 	return &ecr.DescribeRepositoriesOutput{}, testError
 }
 
-func (e ECRErrorMock) DescribeImages(cfg *ecr.DescribeImagesInput) (*ecr.DescribeImagesOutput, error) {
+func (e ECRErrorMock) DescribeImagesWithContext(ctx aws.Context, cfg *ecr.DescribeImagesInput, opts ...request.Option) (*ecr.DescribeImagesOutput, error) {
 	return &ecr.DescribeImagesOutput{}, testError
 }
 
@@ -133,7 +155,7 @@ func TestCanLoadECRImages(t *testing.T) {
 	d := New(logrus.New(), TestClients{ECR: ECRMock{}})
 
 	var rows []inventory.Row
-	d.Load([]string{DefaultRegion}, []string{ServiceECR}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceECR}, func(row inventory.Row) error {
 		rows = append(rows, row)
 		return nil
 	})
@@ -154,7 +176,7 @@ func TestLoadECRImagesLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{ECR: ECRErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceECR}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceECR}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
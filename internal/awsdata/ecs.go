@@ -1,6 +1,7 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -21,9 +22,13 @@ const (
 	ServiceECS string = "ecs"
 )
 
-func (d *AWSData) loadECSContainers(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceECS, ecs.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadECSContainers(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadECSContainers(ctx context.Context, region string) {
 	ec2Svc := d.clients.GetEC2Client(region)
 	ecsSvc := d.clients.GetECSClient(region)
 
@@ -38,9 +43,13 @@ func (d *AWSData) loadECSContainers(region string) {
 	done := false
 	params := &ecs.ListClustersInput{}
 	for !done {
-		out, err := ecsSvc.ListClusters(params)
+		var out *ecs.ListClustersOutput
+		err := d.retry(ctx, ServiceECS, func() (err error) {
+			out, err = ecsSvc.ListClustersWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to list clusters: %s", err)
+			d.AddError(ServiceECS, region, fmt.Errorf("failed to list clusters: %w", err))
 			return
 		}
 
@@ -59,11 +68,15 @@ func (d *AWSData) loadECSContainers(region string) {
 	}
 
 	// TODO: API call can only handle 100 cluster ARNs at a time
-	out, err := ecsSvc.DescribeClusters(&ecs.DescribeClustersInput{
-		Clusters: clusterArns,
+	var out *ecs.DescribeClustersOutput
+	err := d.retry(ctx, ServiceECS, func() (err error) {
+		out, err = ecsSvc.DescribeClustersWithContext(ctx, &ecs.DescribeClustersInput{
+			Clusters: clusterArns,
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to describe clusters: %s", err)
+		d.AddError(ServiceECS, region, fmt.Errorf("failed to describe clusters: %w", err))
 		return
 	}
 
@@ -71,13 +84,13 @@ func (d *AWSData) loadECSContainers(region string) {
 
 	for _, cluster := range out.Clusters {
 		d.wg.Add(1)
-		go d.processECSCluster(log, ecsSvc, ec2Svc, cluster, region)
+		go d.processECSCluster(ctx, log, ecsSvc, ec2Svc, cluster, region)
 	}
 
 	log.Info("finished processing data")
 }
 
-func (d *AWSData) processECSCluster(log *logrus.Entry, ecsSvc ecsiface.ECSAPI, ec2Svc ec2iface.EC2API, cluster *ecs.Cluster, region string) {
+func (d *AWSData) processECSCluster(ctx context.Context, log *logrus.Entry, ecsSvc ecsiface.ECSAPI, ec2Svc ec2iface.EC2API, cluster *ecs.Cluster, region string) {
 	defer d.wg.Done()
 
 	var taskArns []*string
@@ -87,9 +100,13 @@ func (d *AWSData) processECSCluster(log *logrus.Entry, ecsSvc ecsiface.ECSAPI, e
 	}
 
 	for !done {
-		outListTasks, err := ecsSvc.ListTasks(params)
+		var outListTasks *ecs.ListTasksOutput
+		err := d.retry(ctx, ServiceECS, func() (err error) {
+			outListTasks, err = ecsSvc.ListTasksWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to list tasks: %s", err)
+			d.AddError(ServiceECS, region, fmt.Errorf("failed to list tasks: %w", err))
 			return
 		}
 
@@ -108,23 +125,28 @@ func (d *AWSData) processECSCluster(log *logrus.Entry, ecsSvc ecsiface.ECSAPI, e
 	}
 
 	// TODO: API call can only handle 100 task ARNs at a time
-	outDescribeTasks, err := ecsSvc.DescribeTasks(&ecs.DescribeTasksInput{
-		Cluster: cluster.ClusterArn,
-		Tasks:   taskArns,
+	var outDescribeTasks *ecs.DescribeTasksOutput
+	err := d.retry(ctx, ServiceECS, func() (err error) {
+		outDescribeTasks, err = ecsSvc.DescribeTasksWithContext(ctx, &ecs.DescribeTasksInput{
+			Cluster: cluster.ClusterArn,
+			Tasks:   taskArns,
+			Include: aws.StringSlice([]string{ecs.TaskFieldTags}),
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to describe tasks: %s", err)
+		d.AddError(ServiceECS, region, fmt.Errorf("failed to describe tasks: %w", err))
 		return
 	}
 	for _, task := range outDescribeTasks.Tasks {
 		for _, container := range task.Containers {
 			d.wg.Add(1)
-			go d.processECSContainer(log, ec2Svc, container, task, cluster, region)
+			go d.processECSContainer(ctx, log, ec2Svc, container, task, cluster, region)
 		}
 	}
 }
 
-func (d *AWSData) processECSContainer(log *logrus.Entry, ec2Svc ec2iface.EC2API, container *ecs.Container, task *ecs.Task, cluster *ecs.Cluster, region string) {
+func (d *AWSData) processECSContainer(ctx context.Context, log *logrus.Entry, ec2Svc ec2iface.EC2API, container *ecs.Container, task *ecs.Task, cluster *ecs.Cluster, region string) {
 	defer d.wg.Done()
 
 	var ips []string
@@ -164,16 +186,20 @@ func (d *AWSData) processECSContainer(log *logrus.Entry, ec2Svc ec2iface.EC2API,
 	}
 
 	var vpcID string
-	out, err := ec2Svc.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
-		NetworkInterfaceIds: aws.StringSlice(networkInterfaces),
+	var out *ec2.DescribeNetworkInterfacesOutput
+	err := d.retry(ctx, ServiceEC2, func() (err error) {
+		out, err = ec2Svc.DescribeNetworkInterfacesWithContext(ctx, &ec2.DescribeNetworkInterfacesInput{
+			NetworkInterfaceIds: aws.StringSlice(networkInterfaces),
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to describe network interfaces for %s: %s", aws.StringValue(container.Name), err)
+		d.AddError(ServiceECS, region, fmt.Errorf("failed to describe network interfaces for %s: %w", aws.StringValue(container.Name), err))
 	} else if len(out.NetworkInterfaces) > 0 {
 		vpcID = aws.StringValue(out.NetworkInterfaces[0].VpcId)
 	}
 
-	d.rows <- inventory.Row{
+	d.AddRow(ServiceECS, region, inventory.Row{
 		UniqueAssetIdentifier:     fmt.Sprintf("%s-%s", aws.StringValue(container.Name), aws.StringValue(container.RuntimeId)),
 		IPv4orIPv6Address:         strings.Join(ips, "\n"),
 		Virtual:                   true,
@@ -185,5 +211,6 @@ func (d *AWSData) processECSContainer(log *logrus.Entry, ec2Svc ec2iface.EC2API,
 		Function:                  fmt.Sprintf("%s %s", aws.StringValue(cluster.ClusterName), aws.StringValue(task.Group)),
 		SerialAssetTagNumber:      aws.StringValue(container.ContainerArn),
 		VLANNetworkID:             vpcID,
-	}
+		Tags:                      tagMap(len(task.Tags), func(i int) (*string, *string) { return task.Tags[i].Key, task.Tags[i].Value }),
+	})
 }
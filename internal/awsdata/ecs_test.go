@@ -1,10 +1,12 @@
 package awsdata_test
 
 import (
+	"context"
 	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
@@ -246,7 +248,7 @@ type ECSMock struct {
 	ecsiface.ECSAPI
 }
 
-func (e ECSMock) ListClusters(cfg *ecs.ListClustersInput) (*ecs.ListClustersOutput, error) {
+func (e ECSMock) ListClustersWithContext(ctx aws.Context, cfg *ecs.ListClustersInput, opts ...request.Option) (*ecs.ListClustersOutput, error) {
 	if cfg.NextToken == nil {
 		return testECSListClustersOutputPage1, nil
 	}
@@ -254,11 +256,11 @@ func (e ECSMock) ListClusters(cfg *ecs.ListClustersInput) (*ecs.ListClustersOutp
 	return testECSListClustersOutputPage2, nil
 }
 
-func (e ECSMock) DescribeClusters(cfg *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error) {
+func (e ECSMock) DescribeClustersWithContext(ctx aws.Context, cfg *ecs.DescribeClustersInput, opts ...request.Option) (*ecs.DescribeClustersOutput, error) {
 	return testECSDescribeClustersOutput, nil
 }
 
-func (e ECSMock) ListTasks(cfg *ecs.ListTasksInput) (*ecs.ListTasksOutput, error) {
+func (e ECSMock) ListTasksWithContext(ctx aws.Context, cfg *ecs.ListTasksInput, opts ...request.Option) (*ecs.ListTasksOutput, error) {
 	if cfg.NextToken == nil {
 		return testECSListTasksOutputPage1, nil
 	}
@@ -266,7 +268,7 @@ func (e ECSMock) ListTasks(cfg *ecs.ListTasksInput) (*ecs.ListTasksOutput, error
 	return testECSListTasksOutputPage2, nil
 }
 
-func (e ECSMock) DescribeTasks(cfg *ecs.DescribeTasksInput) (*ecs.DescribeTasksOutput, error) {
+func (e ECSMock) DescribeTasksWithContext(ctx aws.Context, cfg *ecs.DescribeTasksInput, opts ...request.Option) (*ecs.DescribeTasksOutput, error) {
 	if cfg.Cluster == testECSListClustersOutputPage2.ClusterArns[0] {
 		return &ecs.DescribeTasksOutput{}, nil
 	}
@@ -274,7 +276,7 @@ func (e ECSMock) DescribeTasks(cfg *ecs.DescribeTasksInput) (*ecs.DescribeTasksO
 	return testECSDescribeTasksOutput, nil
 }
 
-func (e EC2Mock) DescribeNetworkInterfaces(cfg *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+func (e EC2Mock) DescribeNetworkInterfacesWithContext(ctx aws.Context, cfg *ec2.DescribeNetworkInterfacesInput, opts ...request.Option) (*ec2.DescribeNetworkInterfacesOutput, error) {
 	return testEC2DescribeNetworkInterfacesOutput, nil
 }
 
@@ -282,23 +284,23 @@ type ECSErrorMock struct {
 	ecsiface.ECSAPI
 }
 
-func (e ECSErrorMock) ListClusters(cfg *ecs.ListClustersInput) (*ecs.ListClustersOutput, error) {
+func (e ECSErrorMock) ListClustersWithContext(ctx aws.Context, cfg *ecs.ListClustersInput, opts ...request.Option) (*ecs.ListClustersOutput, error) {
 	return &ecs.ListClustersOutput{}, testError
 }
 
-func (e ECSErrorMock) DescribeClusters(cfg *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error) {
+func (e ECSErrorMock) DescribeClustersWithContext(ctx aws.Context, cfg *ecs.DescribeClustersInput, opts ...request.Option) (*ecs.DescribeClustersOutput, error) {
 	return &ecs.DescribeClustersOutput{}, testError
 }
 
-func (e ECSErrorMock) ListTasks(cfg *ecs.ListTasksInput) (*ecs.ListTasksOutput, error) {
+func (e ECSErrorMock) ListTasksWithContext(ctx aws.Context, cfg *ecs.ListTasksInput, opts ...request.Option) (*ecs.ListTasksOutput, error) {
 	return &ecs.ListTasksOutput{}, testError
 }
 
-func (e ECSErrorMock) DescribeTasks(cfg *ecs.DescribeTasksInput) (*ecs.DescribeTasksOutput, error) {
+func (e ECSErrorMock) DescribeTasksWithContext(ctx aws.Context, cfg *ecs.DescribeTasksInput, opts ...request.Option) (*ecs.DescribeTasksOutput, error) {
 	return &ecs.DescribeTasksOutput{}, testError
 }
 
-func (e EC2ErrorMock) DescribeNetworkInterfaces(cfg *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+func (e EC2ErrorMock) DescribeNetworkInterfacesWithContext(ctx aws.Context, cfg *ec2.DescribeNetworkInterfacesInput, opts ...request.Option) (*ec2.DescribeNetworkInterfacesOutput, error) {
 	return &ec2.DescribeNetworkInterfacesOutput{}, testError
 }
 
@@ -307,7 +309,7 @@ func TestCanLoadECSContainers(t *testing.T) {
 	d := New(logrus.New(), TestClients{EC2: EC2Mock{}, ECS: ECSMock{}})
 
 	var rows []inventory.Row
-	d.Load([]string{DefaultRegion}, []string{ServiceECS}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceECS}, func(row inventory.Row) error {
 		rows = append(rows, row)
 		return nil
 	})
@@ -328,7 +330,7 @@ func TestLoadECSContainersLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{EC2: EC2ErrorMock{}, ECS: ECSErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceECS}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceECS}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
@@ -0,0 +1,154 @@
+package awsdata
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/manywho/awsinventory/internal/inventory"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AssetTypeEFSFileSystem is the value used in the AssetType field when fetching EFS file systems
+	AssetTypeEFSFileSystem string = "EFS File System"
+
+	// AssetTypeEFSMountTarget is the value used in the AssetType field when fetching EFS mount targets
+	AssetTypeEFSMountTarget string = "EFS Mount Target"
+
+	// ServiceEFS is the key for the EFS service
+	ServiceEFS string = "efs"
+)
+
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceEFS, efs.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadEFSFileSystems(ctx, region)
+	}))
+}
+
+func (d *AWSData) loadEFSFileSystems(ctx context.Context, region string) {
+	efsSvc := d.clients.GetEFSClient(region)
+
+	log := d.log.WithFields(logrus.Fields{
+		"region":  region,
+		"service": ServiceEFS,
+	})
+
+	log.Info("loading data")
+
+	var fileSystems []*efs.FileSystemDescription
+	done := false
+	params := &efs.DescribeFileSystemsInput{}
+	for !done {
+		var out *efs.DescribeFileSystemsOutput
+		err := d.retry(ctx, ServiceEFS, func() (err error) {
+			out, err = efsSvc.DescribeFileSystemsWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceEFS, region, fmt.Errorf("failed to describe file systems: %w", err))
+			return
+		}
+
+		fileSystems = append(fileSystems, out.FileSystems...)
+
+		if out.NextMarker == nil {
+			done = true
+		} else {
+			params.Marker = out.NextMarker
+		}
+	}
+
+	if len(fileSystems) == 0 {
+		log.Info("no data found; bailing early")
+		return
+	}
+
+	log.Info("processing data")
+
+	for _, fs := range fileSystems {
+		d.wg.Add(1)
+		go d.processEFSFileSystem(ctx, log, efsSvc, fs, region)
+	}
+
+	log.Info("finished processing data")
+}
+
+func (d *AWSData) processEFSFileSystem(ctx context.Context, log *logrus.Entry, efsSvc efsiface.EFSAPI, fs *efs.FileSystemDescription, region string) {
+	defer d.wg.Done()
+
+	var mountTargets []*efs.MountTargetDescription
+	done := false
+	params := &efs.DescribeMountTargetsInput{
+		FileSystemId: fs.FileSystemId,
+	}
+	for !done {
+		var out *efs.DescribeMountTargetsOutput
+		err := d.retry(ctx, ServiceEFS, func() (err error) {
+			out, err = efsSvc.DescribeMountTargetsWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceEFS, region, fmt.Errorf("failed to describe mount targets for %s: %w", aws.StringValue(fs.FileSystemId), err))
+			break
+		}
+
+		mountTargets = append(mountTargets, out.MountTargets...)
+
+		if out.NextMarker == nil {
+			done = true
+		} else {
+			params.Marker = out.NextMarker
+		}
+	}
+
+	tags := tagMap(len(fs.Tags), func(i int) (*string, *string) { return fs.Tags[i].Key, fs.Tags[i].Value })
+
+	// Mount targets have no ARN or tags of their own, so they take their tags from the file system
+	var vpcID string
+	for _, mt := range mountTargets {
+		vpcID = aws.StringValue(mt.VpcId)
+
+		d.AddRow(ServiceEFS, region, inventory.Row{
+			UniqueAssetIdentifier: aws.StringValue(mt.MountTargetId),
+			IPv4orIPv6Address:     aws.StringValue(mt.IpAddress),
+			Virtual:               true,
+			Location:              region,
+			AssetType:             AssetTypeEFSMountTarget,
+			Function:              aws.StringValue(fs.FileSystemId),
+			Comments:              "Subnet: " + aws.StringValue(mt.SubnetId),
+			SerialAssetTagNumber:  aws.StringValue(mt.MountTargetId),
+			VLANNetworkID:         aws.StringValue(mt.VpcId),
+			Tags:                  tags,
+		})
+	}
+
+	var size int64
+	if fs.SizeInBytes != nil {
+		size = aws.Int64Value(fs.SizeInBytes.Value)
+	}
+
+	d.AddRow(ServiceEFS, region, inventory.Row{
+		UniqueAssetIdentifier: aws.StringValue(fs.FileSystemId),
+		Virtual:               true,
+		Location:              region,
+		AssetType:             AssetTypeEFSFileSystem,
+		HardwareMakeModel:     fmt.Sprintf("%s, %s", aws.StringValue(fs.PerformanceMode), aws.StringValue(fs.ThroughputMode)),
+		Function:              aws.StringValue(fs.Name),
+		Comments:              fileSystemComments(size, aws.BoolValue(fs.Encrypted)),
+		SerialAssetTagNumber:  aws.StringValue(fs.FileSystemArn),
+		VLANNetworkID:         vpcID,
+		Tags:                  tags,
+	})
+}
+
+// fileSystemComments returns the size of a file system and whether it is encrypted, for the Comments field
+func fileSystemComments(size int64, encrypted bool) string {
+	if encrypted {
+		return humanReadableBytes(size) + ", encrypted"
+	}
+
+	return humanReadableBytes(size) + ", not encrypted"
+}
@@ -0,0 +1,197 @@
+package awsdata_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+var testEFSRows = []inventory.Row{
+	{
+		UniqueAssetIdentifier: "fs-12345678",
+		Virtual:               true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeEFSFileSystem,
+		HardwareMakeModel:     "generalPurpose, bursting",
+		Function:              "test shared files",
+		Comments:              "6.0 kB, encrypted",
+		SerialAssetTagNumber:  "arn:aws:elasticfilesystem:us-east-1:123456789012:file-system/fs-12345678",
+		VLANNetworkID:         "vpc-12345678",
+		Tags:                  map[string]string{"Name": "test shared files"},
+	},
+	{
+		UniqueAssetIdentifier: "fs-23456789",
+		Virtual:               true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeEFSFileSystem,
+		HardwareMakeModel:     "maxIO, provisioned",
+		Comments:              "1.5 GB, not encrypted",
+		SerialAssetTagNumber:  "arn:aws:elasticfilesystem:us-east-1:123456789012:file-system/fs-23456789",
+	},
+	{
+		UniqueAssetIdentifier: "fsmt-12345678",
+		IPv4orIPv6Address:     "10.0.1.50",
+		Virtual:               true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeEFSMountTarget,
+		Function:              "fs-12345678",
+		Comments:              "Subnet: subnet-12345678",
+		SerialAssetTagNumber:  "fsmt-12345678",
+		VLANNetworkID:         "vpc-12345678",
+		Tags:                  map[string]string{"Name": "test shared files"},
+	},
+	{
+		UniqueAssetIdentifier: "fsmt-23456789",
+		IPv4orIPv6Address:     "10.0.2.50",
+		Virtual:               true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeEFSMountTarget,
+		Function:              "fs-12345678",
+		Comments:              "Subnet: subnet-23456789",
+		SerialAssetTagNumber:  "fsmt-23456789",
+		VLANNetworkID:         "vpc-12345678",
+		Tags:                  map[string]string{"Name": "test shared files"},
+	},
+}
+
+// Test Data
+var testEFSDescribeFileSystemsOutputPage1 = &efs.DescribeFileSystemsOutput{
+	FileSystems: []*efs.FileSystemDescription{
+		{
+			FileSystemId:    aws.String("fs-12345678"),
+			FileSystemArn:   aws.String("arn:aws:elasticfilesystem:us-east-1:123456789012:file-system/fs-12345678"),
+			Name:            aws.String("test shared files"),
+			Encrypted:       aws.Bool(true),
+			PerformanceMode: aws.String(efs.PerformanceModeGeneralPurpose),
+			ThroughputMode:  aws.String(efs.ThroughputModeBursting),
+			SizeInBytes: &efs.FileSystemSize{
+				Value: aws.Int64(6144),
+			},
+			Tags: []*efs.Tag{
+				{
+					Key:   aws.String("Name"),
+					Value: aws.String("test shared files"),
+				},
+			},
+		},
+	},
+	NextMarker: aws.String("fs-23456789"),
+}
+
+var testEFSDescribeFileSystemsOutputPage2 = &efs.DescribeFileSystemsOutput{
+	FileSystems: []*efs.FileSystemDescription{
+		{
+			FileSystemId:    aws.String("fs-23456789"),
+			FileSystemArn:   aws.String("arn:aws:elasticfilesystem:us-east-1:123456789012:file-system/fs-23456789"),
+			Encrypted:       aws.Bool(false),
+			PerformanceMode: aws.String(efs.PerformanceModeMaxIo),
+			ThroughputMode:  aws.String(efs.ThroughputModeProvisioned),
+			SizeInBytes: &efs.FileSystemSize{
+				Value: aws.Int64(1610612736),
+			},
+		},
+	},
+}
+
+var testEFSDescribeMountTargetsOutputs = map[string][]*efs.DescribeMountTargetsOutput{
+	"fs-12345678": {
+		{
+			MountTargets: []*efs.MountTargetDescription{
+				{
+					MountTargetId: aws.String("fsmt-12345678"),
+					FileSystemId:  aws.String("fs-12345678"),
+					IpAddress:     aws.String("10.0.1.50"),
+					SubnetId:      aws.String("subnet-12345678"),
+					VpcId:         aws.String("vpc-12345678"),
+				},
+			},
+			NextMarker: aws.String("fsmt-23456789"),
+		},
+		{
+			MountTargets: []*efs.MountTargetDescription{
+				{
+					MountTargetId: aws.String("fsmt-23456789"),
+					FileSystemId:  aws.String("fs-12345678"),
+					IpAddress:     aws.String("10.0.2.50"),
+					SubnetId:      aws.String("subnet-23456789"),
+					VpcId:         aws.String("vpc-12345678"),
+				},
+			},
+		},
+	},
+	"fs-23456789": {
+		{},
+	},
+}
+
+// Mocks
+type EFSMock struct {
+	efsiface.EFSAPI
+}
+
+func (e EFSMock) DescribeFileSystemsWithContext(ctx aws.Context, cfg *efs.DescribeFileSystemsInput, opts ...request.Option) (*efs.DescribeFileSystemsOutput, error) {
+	if cfg.Marker == nil {
+		return testEFSDescribeFileSystemsOutputPage1, nil
+	}
+
+	return testEFSDescribeFileSystemsOutputPage2, nil
+}
+
+func (e EFSMock) DescribeMountTargetsWithContext(ctx aws.Context, cfg *efs.DescribeMountTargetsInput, opts ...request.Option) (*efs.DescribeMountTargetsOutput, error) {
+	pages := testEFSDescribeMountTargetsOutputs[aws.StringValue(cfg.FileSystemId)]
+	if cfg.Marker == nil {
+		return pages[0], nil
+	}
+
+	return pages[1], nil
+}
+
+type EFSErrorMock struct {
+	efsiface.EFSAPI
+}
+
+func (e EFSErrorMock) DescribeFileSystemsWithContext(ctx aws.Context, cfg *efs.DescribeFileSystemsInput, opts ...request.Option) (*efs.DescribeFileSystemsOutput, error) {
+	return &efs.DescribeFileSystemsOutput{}, testError
+}
+
+func (e EFSErrorMock) DescribeMountTargetsWithContext(ctx aws.Context, cfg *efs.DescribeMountTargetsInput, opts ...request.Option) (*efs.DescribeMountTargetsOutput, error) {
+	return &efs.DescribeMountTargetsOutput{}, testError
+}
+
+// Tests
+func TestCanLoadEFSFileSystems(t *testing.T) {
+	d := New(logrus.New(), TestClients{EFS: EFSMock{}})
+
+	var rows []inventory.Row
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEFS}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	require.Equal(t, testEFSRows, rows)
+}
+
+func TestLoadEFSFileSystemsLogsError(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EFS: EFSErrorMock{}})
+
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEFS}, nil)
+
+	assertTestErrorWasLogged(t, hook.Entries)
+}
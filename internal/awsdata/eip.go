@@ -0,0 +1,120 @@
+package awsdata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/manywho/awsinventory/internal/inventory"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AssetTypeElasticIP is the value used in the AssetType field when fetching Elastic IPs
+	AssetTypeElasticIP string = "Elastic IP"
+
+	// ServiceEIP is the key for the Elastic IPs service
+	ServiceEIP string = "eip"
+)
+
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceEIP, ec2.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadElasticIPs(ctx, region)
+	}))
+}
+
+// loadElasticIPs loads the Elastic IPs in the region. They come from the EC2 API, so their requests are retried
+// and limited as EC2 requests.
+func (d *AWSData) loadElasticIPs(ctx context.Context, region string) {
+	ec2Svc := d.clients.GetEC2Client(region)
+
+	log := d.log.WithFields(logrus.Fields{
+		"region":  region,
+		"service": ServiceEIP,
+	})
+
+	log.Info("loading data")
+
+	var out *ec2.DescribeAddressesOutput
+	err := d.retry(ctx, ServiceEC2, func() (err error) {
+		out, err = ec2Svc.DescribeAddressesWithContext(ctx, &ec2.DescribeAddressesInput{})
+		return
+	})
+	if err != nil {
+		d.AddError(ServiceEIP, region, fmt.Errorf("failed to describe addresses: %w", err))
+		return
+	}
+
+	// The network interfaces of the addresses give their VPC, and their owner when not associated with an instance
+	var networkInterfaceIDs []*string
+	for _, a := range out.Addresses {
+		if a.NetworkInterfaceId != nil {
+			networkInterfaceIDs = append(networkInterfaceIDs, a.NetworkInterfaceId)
+		}
+	}
+
+	networkInterfaces := make(map[string]*ec2.NetworkInterface)
+	if len(networkInterfaceIDs) > 0 {
+		var outNetworkInterfaces *ec2.DescribeNetworkInterfacesOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			outNetworkInterfaces, err = ec2Svc.DescribeNetworkInterfacesWithContext(ctx, &ec2.DescribeNetworkInterfacesInput{
+				NetworkInterfaceIds: networkInterfaceIDs,
+			})
+			return
+		})
+		if err != nil {
+			log.Warningf("failed to describe network interfaces: %s", err)
+		} else {
+			for _, ni := range outNetworkInterfaces.NetworkInterfaces {
+				networkInterfaces[aws.StringValue(ni.NetworkInterfaceId)] = ni
+			}
+		}
+	}
+
+	log.Info("processing data")
+
+	for _, a := range out.Addresses {
+		id := aws.StringValue(a.AllocationId)
+		if id == "" {
+			id = aws.StringValue(a.PublicIp)
+		}
+
+		ips := []string{aws.StringValue(a.PublicIp)}
+		if aws.StringValue(a.PrivateIpAddress) != "" {
+			ips = append(ips, aws.StringValue(a.PrivateIpAddress))
+		}
+
+		var vpcID string
+		comments := "Not associated"
+		ni, ok := networkInterfaces[aws.StringValue(a.NetworkInterfaceId)]
+		if ok {
+			vpcID = aws.StringValue(ni.VpcId)
+		}
+		switch {
+		case aws.StringValue(a.InstanceId) != "":
+			comments = "Attached to: EC2 instance " + aws.StringValue(a.InstanceId)
+		case ok && networkInterfaceOwner(ni) != "":
+			comments = "Attached to: " + networkInterfaceOwner(ni)
+		case a.NetworkInterfaceId != nil:
+			comments = "Attached to: network interface " + aws.StringValue(a.NetworkInterfaceId)
+		}
+
+		d.AddRow(ServiceEIP, region, inventory.Row{
+			UniqueAssetIdentifier: id,
+			IPv4orIPv6Address:     strings.Join(ips, "\n"),
+			Virtual:               true,
+			Public:                true,
+			Location:              region,
+			AssetType:             AssetTypeElasticIP,
+			Function:              ec2Name(a.Tags),
+			Comments:              comments,
+			SerialAssetTagNumber:  id,
+			VLANNetworkID:         vpcID,
+			Tags:                  ec2Tags(a.Tags),
+		})
+	}
+
+	log.Info("finished processing data")
+}
@@ -0,0 +1,162 @@
+package awsdata_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+var testElasticIPRows = []inventory.Row{
+	{
+		UniqueAssetIdentifier: "eipalloc-12345678",
+		IPv4orIPv6Address:     "203.0.113.10\n10.0.1.25",
+		Virtual:               true,
+		Public:                true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeElasticIP,
+		Comments:              "Attached to: Interface for NAT Gateway nat-0123456789abcdef0",
+		SerialAssetTagNumber:  "eipalloc-12345678",
+		VLANNetworkID:         "vpc-12345678",
+	},
+	{
+		UniqueAssetIdentifier: "eipalloc-23456789",
+		IPv4orIPv6Address:     "203.0.113.20\n10.0.1.10",
+		Virtual:               true,
+		Public:                true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeElasticIP,
+		Function:              "test app 1 ip",
+		Comments:              "Attached to: EC2 instance i-1234567890abcdef0",
+		SerialAssetTagNumber:  "eipalloc-23456789",
+		VLANNetworkID:         "vpc-12345678",
+		Tags:                  map[string]string{"Name": "test app 1 ip"},
+	},
+	{
+		UniqueAssetIdentifier: "eipalloc-34567890",
+		IPv4orIPv6Address:     "198.51.100.5",
+		Virtual:               true,
+		Public:                true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeElasticIP,
+		Comments:              "Not associated",
+		SerialAssetTagNumber:  "eipalloc-34567890",
+	},
+}
+
+// Test Data
+var testEC2DescribeAddressesOutput = &ec2.DescribeAddressesOutput{
+	Addresses: []*ec2.Address{
+		{
+			AllocationId:       aws.String("eipalloc-12345678"),
+			AssociationId:      aws.String("eipassoc-12345678"),
+			Domain:             aws.String(ec2.DomainTypeVpc),
+			NetworkInterfaceId: aws.String("eni-55555555"),
+			PrivateIpAddress:   aws.String("10.0.1.25"),
+			PublicIp:           aws.String("203.0.113.10"),
+		},
+		{
+			AllocationId:       aws.String("eipalloc-23456789"),
+			AssociationId:      aws.String("eipassoc-23456789"),
+			Domain:             aws.String(ec2.DomainTypeVpc),
+			InstanceId:         aws.String("i-1234567890abcdef0"),
+			NetworkInterfaceId: aws.String("eni-11111111"),
+			PrivateIpAddress:   aws.String("10.0.1.10"),
+			PublicIp:           aws.String("203.0.113.20"),
+			Tags: []*ec2.Tag{
+				{
+					Key:   aws.String("Name"),
+					Value: aws.String("test app 1 ip"),
+				},
+			},
+		},
+		{
+			AllocationId: aws.String("eipalloc-34567890"),
+			Domain:       aws.String(ec2.DomainTypeVpc),
+			PublicIp:     aws.String("198.51.100.5"),
+		},
+	},
+}
+
+// Mocks
+type EIPMock struct {
+	ENIMock
+}
+
+func (e EIPMock) DescribeAddressesWithContext(ctx aws.Context, cfg *ec2.DescribeAddressesInput, opts ...request.Option) (*ec2.DescribeAddressesOutput, error) {
+	return testEC2DescribeAddressesOutput, nil
+}
+
+func (e EC2ErrorMock) DescribeAddressesWithContext(ctx aws.Context, cfg *ec2.DescribeAddressesInput, opts ...request.Option) (*ec2.DescribeAddressesOutput, error) {
+	return &ec2.DescribeAddressesOutput{}, testError
+}
+
+type EIPNetworkInterfacesErrorMock struct {
+	ec2iface.EC2API
+}
+
+func (e EIPNetworkInterfacesErrorMock) DescribeAddressesWithContext(ctx aws.Context, cfg *ec2.DescribeAddressesInput, opts ...request.Option) (*ec2.DescribeAddressesOutput, error) {
+	return testEC2DescribeAddressesOutput, nil
+}
+
+func (e EIPNetworkInterfacesErrorMock) DescribeNetworkInterfacesWithContext(ctx aws.Context, cfg *ec2.DescribeNetworkInterfacesInput, opts ...request.Option) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return &ec2.DescribeNetworkInterfacesOutput{}, testError
+}
+
+// Tests
+func TestCanLoadElasticIPs(t *testing.T) {
+	d := New(logrus.New(), TestClients{EC2: EIPMock{}})
+
+	var rows []inventory.Row
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEIP}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	require.Equal(t, testElasticIPRows, rows)
+}
+
+func TestLoadElasticIPsLogsError(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EC2ErrorMock{}})
+
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEIP}, nil)
+
+	assertTestErrorWasLogged(t, hook.Entries)
+}
+
+func TestLoadElasticIPsKeepsAddressesWhenNetworkInterfacesFail(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EIPNetworkInterfacesErrorMock{}})
+
+	var rows []inventory.Row
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEIP}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	require.True(t, report.Complete())
+	require.Len(t, rows, 3)
+	require.Equal(t, "Attached to: network interface eni-55555555", rows[0].Comments)
+	require.Equal(t, "", rows[0].VLANNetworkID)
+}
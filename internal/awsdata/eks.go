@@ -0,0 +1,190 @@
+package awsdata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/manywho/awsinventory/internal/inventory"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AssetTypeEKSCluster is the value used in the AssetType field when fetching EKS clusters
+	AssetTypeEKSCluster string = "EKS Cluster"
+
+	// AssetTypeEKSNodeGroup is the value used in the AssetType field when fetching EKS managed node groups
+	AssetTypeEKSNodeGroup string = "EKS Node Group"
+
+	// ServiceEKS is the key for the EKS service
+	ServiceEKS string = "eks"
+)
+
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceEKS, eks.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadEKSClusters(ctx, region)
+	}))
+}
+
+func (d *AWSData) loadEKSClusters(ctx context.Context, region string) {
+	eksSvc := d.clients.GetEKSClient(region)
+
+	log := d.log.WithFields(logrus.Fields{
+		"region":  region,
+		"service": ServiceEKS,
+	})
+
+	log.Info("loading data")
+
+	var clusterNames []*string
+	done := false
+	params := &eks.ListClustersInput{}
+	for !done {
+		var out *eks.ListClustersOutput
+		err := d.retry(ctx, ServiceEKS, func() (err error) {
+			out, err = eksSvc.ListClustersWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceEKS, region, fmt.Errorf("failed to list clusters: %w", err))
+			return
+		}
+
+		clusterNames = append(clusterNames, out.Clusters...)
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	if len(clusterNames) == 0 {
+		log.Info("no data found; bailing early")
+		return
+	}
+
+	log.Info("processing data")
+
+	for _, name := range clusterNames {
+		d.wg.Add(1)
+		go d.processEKSCluster(ctx, log, eksSvc, name, region)
+	}
+
+	log.Info("finished processing data")
+}
+
+func (d *AWSData) processEKSCluster(ctx context.Context, log *logrus.Entry, eksSvc eksiface.EKSAPI, name *string, region string) {
+	defer d.wg.Done()
+
+	var out *eks.DescribeClusterOutput
+	err := d.retry(ctx, ServiceEKS, func() (err error) {
+		out, err = eksSvc.DescribeClusterWithContext(ctx, &eks.DescribeClusterInput{
+			Name: name,
+		})
+		return
+	})
+	if err != nil {
+		d.AddError(ServiceEKS, region, fmt.Errorf("failed to describe cluster %s: %w", aws.StringValue(name), err))
+		return
+	}
+
+	cluster := out.Cluster
+
+	var vpcID string
+	var public bool
+	if cluster.ResourcesVpcConfig != nil {
+		vpcID = aws.StringValue(cluster.ResourcesVpcConfig.VpcId)
+		public = aws.BoolValue(cluster.ResourcesVpcConfig.EndpointPublicAccess)
+	}
+
+	d.AddRow(ServiceEKS, region, inventory.Row{
+		UniqueAssetIdentifier:          aws.StringValue(cluster.Name),
+		Virtual:                        true,
+		Public:                         public,
+		DNSNameOrURL:                   aws.StringValue(cluster.Endpoint),
+		Location:                       region,
+		AssetType:                      AssetTypeEKSCluster,
+		SoftwareDatabaseNameAndVersion: fmt.Sprintf("Kubernetes %s", aws.StringValue(cluster.Version)),
+		PatchLevel:                     aws.StringValue(cluster.PlatformVersion),
+		SerialAssetTagNumber:           aws.StringValue(cluster.Arn),
+		VLANNetworkID:                  vpcID,
+		Tags:                           stringMapTags(cluster.Tags),
+	})
+
+	var nodegroupNames []*string
+	done := false
+	params := &eks.ListNodegroupsInput{
+		ClusterName: cluster.Name,
+	}
+	for !done {
+		var outListNodegroups *eks.ListNodegroupsOutput
+		err := d.retry(ctx, ServiceEKS, func() (err error) {
+			outListNodegroups, err = eksSvc.ListNodegroupsWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceEKS, region, fmt.Errorf("failed to list node groups for %s: %w", aws.StringValue(cluster.Name), err))
+			return
+		}
+
+		nodegroupNames = append(nodegroupNames, outListNodegroups.Nodegroups...)
+
+		if outListNodegroups.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = outListNodegroups.NextToken
+		}
+	}
+
+	for _, nodegroupName := range nodegroupNames {
+		d.wg.Add(1)
+		go d.processEKSNodegroup(ctx, log, eksSvc, nodegroupName, cluster, vpcID, region)
+	}
+}
+
+func (d *AWSData) processEKSNodegroup(ctx context.Context, log *logrus.Entry, eksSvc eksiface.EKSAPI, name *string, cluster *eks.Cluster, vpcID string, region string) {
+	defer d.wg.Done()
+
+	var out *eks.DescribeNodegroupOutput
+	err := d.retry(ctx, ServiceEKS, func() (err error) {
+		out, err = eksSvc.DescribeNodegroupWithContext(ctx, &eks.DescribeNodegroupInput{
+			ClusterName:   cluster.Name,
+			NodegroupName: name,
+		})
+		return
+	})
+	if err != nil {
+		d.AddError(ServiceEKS, region, fmt.Errorf("failed to describe node group %s: %w", aws.StringValue(name), err))
+		return
+	}
+
+	nodegroup := out.Nodegroup
+
+	var scaling string
+	if nodegroup.ScalingConfig != nil {
+		scaling = fmt.Sprintf("desired %d, min %d, max %d",
+			aws.Int64Value(nodegroup.ScalingConfig.DesiredSize),
+			aws.Int64Value(nodegroup.ScalingConfig.MinSize),
+			aws.Int64Value(nodegroup.ScalingConfig.MaxSize))
+	}
+
+	d.AddRow(ServiceEKS, region, inventory.Row{
+		UniqueAssetIdentifier:          fmt.Sprintf("%s-%s", aws.StringValue(cluster.Name), aws.StringValue(nodegroup.NodegroupName)),
+		Virtual:                        true,
+		BaselineConfigurationName:      aws.StringValue(nodegroup.ReleaseVersion),
+		OSNameAndVersion:               aws.StringValue(nodegroup.AmiType),
+		Location:                       region,
+		AssetType:                      AssetTypeEKSNodeGroup,
+		HardwareMakeModel:              strings.Join(aws.StringValueSlice(nodegroup.InstanceTypes), ", "),
+		SoftwareDatabaseNameAndVersion: fmt.Sprintf("Kubernetes %s", aws.StringValue(nodegroup.Version)),
+		Function:                       aws.StringValue(cluster.Name),
+		Comments:                       scaling,
+		SerialAssetTagNumber:           aws.StringValue(nodegroup.NodegroupArn),
+		VLANNetworkID:                  vpcID,
+		Tags:                           stringMapTags(nodegroup.Tags),
+	})
+}
@@ -0,0 +1,257 @@
+package awsdata_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+var testEKSRows = []inventory.Row{
+	{
+		UniqueAssetIdentifier:          "eks-cluster-1",
+		Virtual:                        true,
+		Public:                         true,
+		DNSNameOrURL:                   "https://0123456789ABCDEF0123456789ABCDEF.gr7.us-east-1.eks.amazonaws.com",
+		Location:                       DefaultRegion,
+		AssetType:                      AssetTypeEKSCluster,
+		SoftwareDatabaseNameAndVersion: "Kubernetes 1.19",
+		PatchLevel:                     "eks.4",
+		SerialAssetTagNumber:           "arn:aws:eks:us-east-1:123456789012:cluster/eks-cluster-1",
+		VLANNetworkID:                  "vpc-123456789",
+		Tags:                           map[string]string{"Owner": "test owner"},
+	},
+	{
+		UniqueAssetIdentifier:          "eks-cluster-1-ng-1",
+		Virtual:                        true,
+		BaselineConfigurationName:      "1.19.6-20210414",
+		OSNameAndVersion:               "AL2_x86_64",
+		Location:                       DefaultRegion,
+		AssetType:                      AssetTypeEKSNodeGroup,
+		HardwareMakeModel:              "m5.large, m5.xlarge",
+		SoftwareDatabaseNameAndVersion: "Kubernetes 1.19",
+		Function:                       "eks-cluster-1",
+		Comments:                       "desired 3, min 2, max 5",
+		SerialAssetTagNumber:           "arn:aws:eks:us-east-1:123456789012:nodegroup/eks-cluster-1/ng-1/12b3c4d5-6e7f-8a9b-0c1d-2e3f4a5b6c7d",
+		VLANNetworkID:                  "vpc-123456789",
+		Tags:                           map[string]string{"Owner": "test owner"},
+	},
+	{
+		UniqueAssetIdentifier:          "eks-cluster-1-ng-2",
+		Virtual:                        true,
+		BaselineConfigurationName:      "1.19.6-20210414",
+		OSNameAndVersion:               "AL2_ARM_64",
+		Location:                       DefaultRegion,
+		AssetType:                      AssetTypeEKSNodeGroup,
+		HardwareMakeModel:              "m6g.large",
+		SoftwareDatabaseNameAndVersion: "Kubernetes 1.19",
+		Function:                       "eks-cluster-1",
+		Comments:                       "desired 1, min 1, max 1",
+		SerialAssetTagNumber:           "arn:aws:eks:us-east-1:123456789012:nodegroup/eks-cluster-1/ng-2/9a8b7c6d-5e4f-3a2b-1c0d-9e8f7a6b5c4d",
+		VLANNetworkID:                  "vpc-123456789",
+	},
+	{
+		UniqueAssetIdentifier:          "eks-cluster-2",
+		Virtual:                        true,
+		DNSNameOrURL:                   "https://FEDCBA9876543210FEDCBA9876543210.gr7.us-east-1.eks.amazonaws.com",
+		Location:                       DefaultRegion,
+		AssetType:                      AssetTypeEKSCluster,
+		SoftwareDatabaseNameAndVersion: "Kubernetes 1.18",
+		PatchLevel:                     "eks.6",
+		SerialAssetTagNumber:           "arn:aws:eks:us-east-1:123456789012:cluster/eks-cluster-2",
+		VLANNetworkID:                  "vpc-abcdefgh",
+	},
+}
+
+// Test Data
+var testEKSListClustersOutputPage1 = &eks.ListClustersOutput{
+	NextToken: aws.String("eks-cluster-2"),
+	Clusters: []*string{
+		aws.String("eks-cluster-1"),
+	},
+}
+
+var testEKSListClustersOutputPage2 = &eks.ListClustersOutput{
+	Clusters: []*string{
+		aws.String("eks-cluster-2"),
+	},
+}
+
+var testEKSClusters = map[string]*eks.Cluster{
+	"eks-cluster-1": {
+		Arn:             aws.String(testEKSRows[0].SerialAssetTagNumber),
+		Name:            aws.String("eks-cluster-1"),
+		Endpoint:        aws.String(testEKSRows[0].DNSNameOrURL),
+		Version:         aws.String("1.19"),
+		PlatformVersion: aws.String("eks.4"),
+		ResourcesVpcConfig: &eks.VpcConfigResponse{
+			VpcId:                 aws.String("vpc-123456789"),
+			EndpointPublicAccess:  aws.Bool(true),
+			EndpointPrivateAccess: aws.Bool(true),
+		},
+		Tags: map[string]*string{
+			"Owner": aws.String("test owner"),
+		},
+	},
+	"eks-cluster-2": {
+		Arn:             aws.String(testEKSRows[3].SerialAssetTagNumber),
+		Name:            aws.String("eks-cluster-2"),
+		Endpoint:        aws.String(testEKSRows[3].DNSNameOrURL),
+		Version:         aws.String("1.18"),
+		PlatformVersion: aws.String("eks.6"),
+		ResourcesVpcConfig: &eks.VpcConfigResponse{
+			VpcId:                 aws.String("vpc-abcdefgh"),
+			EndpointPublicAccess:  aws.Bool(false),
+			EndpointPrivateAccess: aws.Bool(true),
+		},
+	},
+}
+
+var testEKSListNodegroupsOutputPage1 = &eks.ListNodegroupsOutput{
+	NextToken: aws.String("ng-2"),
+	Nodegroups: []*string{
+		aws.String("ng-1"),
+	},
+}
+
+var testEKSListNodegroupsOutputPage2 = &eks.ListNodegroupsOutput{
+	Nodegroups: []*string{
+		aws.String("ng-2"),
+	},
+}
+
+var testEKSNodegroups = map[string]*eks.Nodegroup{
+	"ng-1": {
+		NodegroupArn:   aws.String(testEKSRows[1].SerialAssetTagNumber),
+		NodegroupName:  aws.String("ng-1"),
+		ClusterName:    aws.String("eks-cluster-1"),
+		AmiType:        aws.String("AL2_x86_64"),
+		InstanceTypes:  aws.StringSlice([]string{"m5.large", "m5.xlarge"}),
+		ReleaseVersion: aws.String("1.19.6-20210414"),
+		Version:        aws.String("1.19"),
+		ScalingConfig: &eks.NodegroupScalingConfig{
+			DesiredSize: aws.Int64(3),
+			MinSize:     aws.Int64(2),
+			MaxSize:     aws.Int64(5),
+		},
+		Tags: map[string]*string{
+			"Owner": aws.String("test owner"),
+		},
+	},
+	"ng-2": {
+		NodegroupArn:   aws.String(testEKSRows[2].SerialAssetTagNumber),
+		NodegroupName:  aws.String("ng-2"),
+		ClusterName:    aws.String("eks-cluster-1"),
+		AmiType:        aws.String("AL2_ARM_64"),
+		InstanceTypes:  aws.StringSlice([]string{"m6g.large"}),
+		ReleaseVersion: aws.String("1.19.6-20210414"),
+		Version:        aws.String("1.19"),
+		ScalingConfig: &eks.NodegroupScalingConfig{
+			DesiredSize: aws.Int64(1),
+			MinSize:     aws.Int64(1),
+			MaxSize:     aws.Int64(1),
+		},
+	},
+}
+
+// Mocks
+type EKSMock struct {
+	eksiface.EKSAPI
+}
+
+func (e EKSMock) ListClustersWithContext(ctx aws.Context, cfg *eks.ListClustersInput, opts ...request.Option) (*eks.ListClustersOutput, error) {
+	if cfg.NextToken == nil {
+		return testEKSListClustersOutputPage1, nil
+	}
+
+	return testEKSListClustersOutputPage2, nil
+}
+
+func (e EKSMock) DescribeClusterWithContext(ctx aws.Context, cfg *eks.DescribeClusterInput, opts ...request.Option) (*eks.DescribeClusterOutput, error) {
+	return &eks.DescribeClusterOutput{
+		Cluster: testEKSClusters[aws.StringValue(cfg.Name)],
+	}, nil
+}
+
+func (e EKSMock) ListNodegroupsWithContext(ctx aws.Context, cfg *eks.ListNodegroupsInput, opts ...request.Option) (*eks.ListNodegroupsOutput, error) {
+	if aws.StringValue(cfg.ClusterName) != "eks-cluster-1" {
+		return &eks.ListNodegroupsOutput{}, nil
+	}
+
+	if cfg.NextToken == nil {
+		return testEKSListNodegroupsOutputPage1, nil
+	}
+
+	return testEKSListNodegroupsOutputPage2, nil
+}
+
+func (e EKSMock) DescribeNodegroupWithContext(ctx aws.Context, cfg *eks.DescribeNodegroupInput, opts ...request.Option) (*eks.DescribeNodegroupOutput, error) {
+	return &eks.DescribeNodegroupOutput{
+		Nodegroup: testEKSNodegroups[aws.StringValue(cfg.NodegroupName)],
+	}, nil
+}
+
+type EKSErrorMock struct {
+	eksiface.EKSAPI
+}
+
+func (e EKSErrorMock) ListClustersWithContext(ctx aws.Context, cfg *eks.ListClustersInput, opts ...request.Option) (*eks.ListClustersOutput, error) {
+	return &eks.ListClustersOutput{}, testError
+}
+
+type EKSNodegroupErrorMock struct {
+	EKSMock
+}
+
+func (e EKSNodegroupErrorMock) ListNodegroupsWithContext(ctx aws.Context, cfg *eks.ListNodegroupsInput, opts ...request.Option) (*eks.ListNodegroupsOutput, error) {
+	return &eks.ListNodegroupsOutput{}, testError
+}
+
+// Tests
+func TestCanLoadEKSClustersAndNodeGroups(t *testing.T) {
+	d := New(logrus.New(), TestClients{EKS: EKSMock{}})
+
+	var rows []inventory.Row
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEKS}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	require.Equal(t, testEKSRows, rows)
+}
+
+func TestLoadEKSClustersLogsError(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EKS: EKSErrorMock{}})
+
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEKS}, nil)
+
+	assertTestErrorWasLogged(t, hook.Entries)
+}
+
+func TestLoadEKSNodeGroupsLogsErrorAndKeepsClusters(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EKS: EKSNodegroupErrorMock{}})
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEKS}, nil)
+
+	require.Equal(t, 2, report.Rows[ServiceEKS][DefaultRegion])
+	require.Len(t, report.Errors, 2)
+	assertTestErrorWasLogged(t, hook.Entries)
+}
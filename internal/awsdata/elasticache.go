@@ -1,6 +1,7 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -18,9 +19,13 @@ const (
 	ServiceElastiCache string = "elasticache"
 )
 
-func (d *AWSData) loadElastiCacheNodes(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceElastiCache, elasticache.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadElastiCacheNodes(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadElastiCacheNodes(ctx context.Context, region string) {
 	elasticacheSvc := d.clients.GetElastiCacheClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -36,9 +41,13 @@ func (d *AWSData) loadElastiCacheNodes(region string) {
 		ShowCacheNodeInfo: aws.Bool(true),
 	}
 	for !done {
-		out, err := elasticacheSvc.DescribeCacheClusters(params)
+		var out *elasticache.DescribeCacheClustersOutput
+		err := d.retry(ctx, ServiceElastiCache, func() (err error) {
+			out, err = elasticacheSvc.DescribeCacheClustersWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to describe clusters: %s", err)
+			d.AddError(ServiceElastiCache, region, fmt.Errorf("failed to describe clusters: %w", err))
 			return
 		}
 
@@ -55,18 +64,22 @@ func (d *AWSData) loadElastiCacheNodes(region string) {
 
 	for _, c := range cacheClusters {
 		d.wg.Add(1)
-		go d.processElastiCacheCacheCluster(log, elasticacheSvc, c, region)
+		go d.processElastiCacheCacheCluster(ctx, log, elasticacheSvc, c, region)
 	}
 
 	log.Info("finished processing data")
 }
 
-func (d *AWSData) processElastiCacheCacheCluster(log *logrus.Entry, elasticacheSvc elasticacheiface.ElastiCacheAPI, cacheCluster *elasticache.CacheCluster, region string) {
+func (d *AWSData) processElastiCacheCacheCluster(ctx context.Context, log *logrus.Entry, elasticacheSvc elasticacheiface.ElastiCacheAPI, cacheCluster *elasticache.CacheCluster, region string) {
 	defer d.wg.Done()
 
 	var vpcID string
-	groups, err := elasticacheSvc.DescribeCacheSubnetGroups(&elasticache.DescribeCacheSubnetGroupsInput{
-		CacheSubnetGroupName: cacheCluster.CacheSubnetGroupName,
+	var groups *elasticache.DescribeCacheSubnetGroupsOutput
+	err := d.retry(ctx, ServiceElastiCache, func() (err error) {
+		groups, err = elasticacheSvc.DescribeCacheSubnetGroupsWithContext(ctx, &elasticache.DescribeCacheSubnetGroupsInput{
+			CacheSubnetGroupName: cacheCluster.CacheSubnetGroupName,
+		})
+		return
 	})
 	if err != nil {
 		log.Warningf("failed to describe cache subnet groups for %s: %s", aws.StringValue(cacheCluster.CacheClusterId), err)
@@ -74,8 +87,22 @@ func (d *AWSData) processElastiCacheCacheCluster(log *logrus.Entry, elasticacheS
 		vpcID = aws.StringValue(groups.CacheSubnetGroups[0].VpcId)
 	}
 
+	var tags map[string]string
+	var outTags *elasticache.TagListMessage
+	err = d.retry(ctx, ServiceElastiCache, func() (err error) {
+		outTags, err = elasticacheSvc.ListTagsForResourceWithContext(ctx, &elasticache.ListTagsForResourceInput{
+			ResourceName: cacheCluster.ARN,
+		})
+		return
+	})
+	if err != nil {
+		d.AddError(ServiceElastiCache, region, fmt.Errorf("failed to list tags for %s: %w", aws.StringValue(cacheCluster.CacheClusterId), err))
+	} else {
+		tags = tagMap(len(outTags.TagList), func(i int) (*string, *string) { return outTags.TagList[i].Key, outTags.TagList[i].Value })
+	}
+
 	for _, n := range cacheCluster.CacheNodes {
-		d.rows <- inventory.Row{
+		d.AddRow(ServiceElastiCache, region, inventory.Row{
 			UniqueAssetIdentifier:          fmt.Sprintf("%s-%s", aws.StringValue(cacheCluster.CacheClusterId), aws.StringValue(n.CacheNodeId)),
 			Virtual:                        true,
 			Public:                         false,
@@ -88,6 +115,7 @@ func (d *AWSData) processElastiCacheCacheCluster(log *logrus.Entry, elasticacheS
 			SoftwareDatabaseNameAndVersion: fmt.Sprintf("%s %s", aws.StringValue(cacheCluster.Engine), aws.StringValue(cacheCluster.EngineVersion)),
 			SerialAssetTagNumber:           aws.StringValue(cacheCluster.ARN),
 			VLANNetworkID:                  vpcID,
-		}
+			Tags:                           tags,
+		})
 	}
 }
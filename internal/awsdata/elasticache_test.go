@@ -1,10 +1,12 @@
 package awsdata_test
 
 import (
+	"context"
 	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
 	"github.com/sirupsen/logrus"
@@ -29,6 +31,9 @@ var testElastiCacheNodeRows = []inventory.Row{
 		SoftwareDatabaseNameAndVersion: "redis 5.2",
 		SerialAssetTagNumber:           "arn:aws:elasticache:us-east-1:123456789012:cluster:test-cluster-1",
 		VLANNetworkID:                  "vpc-12345678",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier:          "test-cluster-1-test-node-1",
@@ -43,6 +48,9 @@ var testElastiCacheNodeRows = []inventory.Row{
 		SoftwareDatabaseNameAndVersion: "redis 5.2",
 		SerialAssetTagNumber:           "arn:aws:elasticache:us-east-1:123456789012:cluster:test-cluster-1",
 		VLANNetworkID:                  "vpc-12345678",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier:          "test-cluster-2-test-node-2",
@@ -203,7 +211,7 @@ type ElastiCacheMock struct {
 	elasticacheiface.ElastiCacheAPI
 }
 
-func (e ElastiCacheMock) DescribeCacheClusters(cfg *elasticache.DescribeCacheClustersInput) (*elasticache.DescribeCacheClustersOutput, error) {
+func (e ElastiCacheMock) DescribeCacheClustersWithContext(ctx aws.Context, cfg *elasticache.DescribeCacheClustersInput, opts ...request.Option) (*elasticache.DescribeCacheClustersOutput, error) {
 	if cfg.Marker == nil {
 		return testElastiCacheDescribeCacheClustersOutputPage1, nil
 	}
@@ -211,19 +219,34 @@ func (e ElastiCacheMock) DescribeCacheClusters(cfg *elasticache.DescribeCacheClu
 	return testElastiCacheDescribeCacheClustersOutputPage2, nil
 }
 
-func (e ElastiCacheMock) DescribeCacheSubnetGroups(cfg *elasticache.DescribeCacheSubnetGroupsInput) (*elasticache.DescribeCacheSubnetGroupsOutput, error) {
+func (e ElastiCacheMock) DescribeCacheSubnetGroupsWithContext(ctx aws.Context, cfg *elasticache.DescribeCacheSubnetGroupsInput, opts ...request.Option) (*elasticache.DescribeCacheSubnetGroupsOutput, error) {
 	return testElastiCacheDescribeCacheSubnetGroupOutput, nil
 }
 
+func (e ElastiCacheMock) ListTagsForResourceWithContext(ctx aws.Context, cfg *elasticache.ListTagsForResourceInput, opts ...request.Option) (*elasticache.TagListMessage, error) {
+	if aws.StringValue(cfg.ResourceName) != testElastiCacheNodeRows[0].SerialAssetTagNumber {
+		return &elasticache.TagListMessage{}, nil
+	}
+
+	return &elasticache.TagListMessage{
+		TagList: []*elasticache.Tag{
+			{
+				Key:   aws.String("Owner"),
+				Value: aws.String("test owner"),
+			},
+		},
+	}, nil
+}
+
 type ElastiCacheErrorMock struct {
 	elasticacheiface.ElastiCacheAPI
 }
 
-func (e ElastiCacheErrorMock) DescribeCacheClusters(cfg *elasticache.DescribeCacheClustersInput) (*elasticache.DescribeCacheClustersOutput, error) {
+func (e ElastiCacheErrorMock) DescribeCacheClustersWithContext(ctx aws.Context, cfg *elasticache.DescribeCacheClustersInput, opts ...request.Option) (*elasticache.DescribeCacheClustersOutput, error) {
 	return &elasticache.DescribeCacheClustersOutput{}, testError
 }
 
-func (e ElastiCacheErrorMock) DescribeCacheSubnetGroups(cfg *elasticache.DescribeCacheSubnetGroupsInput) (*elasticache.DescribeCacheSubnetGroupsOutput, error) {
+func (e ElastiCacheErrorMock) DescribeCacheSubnetGroupsWithContext(ctx aws.Context, cfg *elasticache.DescribeCacheSubnetGroupsInput, opts ...request.Option) (*elasticache.DescribeCacheSubnetGroupsOutput, error) {
 	return &elasticache.DescribeCacheSubnetGroupsOutput{}, testError
 }
 
@@ -232,7 +255,7 @@ func TestCanLoadElastiCacheNodes(t *testing.T) {
 	d := New(logrus.New(), TestClients{ElastiCache: ElastiCacheMock{}})
 
 	var rows []inventory.Row
-	d.Load([]string{DefaultRegion}, []string{ServiceElastiCache}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceElastiCache}, func(row inventory.Row) error {
 		rows = append(rows, row)
 		return nil
 	})
@@ -253,7 +276,7 @@ func TestLoadElastiCacheNodesLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{ElastiCache: ElastiCacheErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceElastiCache}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceElastiCache}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
@@ -1,12 +1,14 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/manywho/awsinventory/internal/inventory"
 	"github.com/sirupsen/logrus"
 )
@@ -19,9 +21,13 @@ const (
 	ServiceELB string = "elb"
 )
 
-func (d *AWSData) loadELBs(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceELB, elb.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadELBs(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadELBs(ctx context.Context, region string) {
 	ec2Svc := d.clients.GetEC2Client(region)
 	elbSvc := d.clients.GetELBClient(region)
 
@@ -38,11 +44,15 @@ func (d *AWSData) loadELBs(region string) {
 	}
 
 	var accountID string
-	out, err := ec2Svc.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
-		MaxResults: aws.Int64(5),
+	var out *ec2.DescribeSecurityGroupsOutput
+	err := d.retry(ctx, ServiceEC2, func() (err error) {
+		out, err = ec2Svc.DescribeSecurityGroupsWithContext(ctx, &ec2.DescribeSecurityGroupsInput{
+			MaxResults: aws.Int64(5),
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to load account if from security groups: %s", err)
+		d.AddError(ServiceELB, region, fmt.Errorf("failed to load account if from security groups: %w", err))
 		return
 	} else if len(out.SecurityGroups) > 0 {
 		accountID = aws.StringValue(out.SecurityGroups[0].OwnerId)
@@ -52,10 +62,13 @@ func (d *AWSData) loadELBs(region string) {
 	done := false
 	params := &elb.DescribeLoadBalancersInput{}
 	for !done {
-		out, err := elbSvc.DescribeLoadBalancers(params)
-
+		var out *elb.DescribeLoadBalancersOutput
+		err := d.retry(ctx, ServiceELB, func() (err error) {
+			out, err = elbSvc.DescribeLoadBalancersWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to describe load balancers: %s", err)
+			d.AddError(ServiceELB, region, fmt.Errorf("failed to describe load balancers: %w", err))
 			return
 		}
 
@@ -70,6 +83,16 @@ func (d *AWSData) loadELBs(region string) {
 
 	log.Info("processing data")
 
+	var names []*string
+	for _, l := range loadBalancers {
+		names = append(names, l.LoadBalancerName)
+	}
+
+	tags, err := d.describeELBTags(ctx, elbSvc, names)
+	if err != nil {
+		d.AddError(ServiceELB, region, fmt.Errorf("failed to describe tags: %w", err))
+	}
+
 	for _, l := range loadBalancers {
 		var public bool
 		if aws.StringValue(l.Scheme) == "internet-facing" {
@@ -78,7 +101,7 @@ func (d *AWSData) loadELBs(region string) {
 			public = false
 		}
 
-		d.rows <- inventory.Row{
+		d.AddRow(ServiceELB, region, inventory.Row{
 			UniqueAssetIdentifier: aws.StringValue(l.LoadBalancerName),
 			Virtual:               true,
 			Public:                public,
@@ -88,8 +111,44 @@ func (d *AWSData) loadELBs(region string) {
 			Function:              aws.StringValue(l.CanonicalHostedZoneName),
 			SerialAssetTagNumber:  fmt.Sprintf("arn:%s:elasticloadbalancing:%s:%s:loadbalancer/%s", partition, region, accountID, aws.StringValue(l.LoadBalancerName)),
 			VLANNetworkID:         aws.StringValue(l.VPCId),
-		}
+			Tags:                  tags[aws.StringValue(l.LoadBalancerName)],
+		})
 	}
 
 	log.Info("finished processing data")
 }
+
+// describeELBTags returns the tags of each of the load balancers which have any, keyed by name.
+// DescribeTags takes up to 20 load balancers at a time.
+func (d *AWSData) describeELBTags(ctx context.Context, elbSvc elbiface.ELBAPI, names []*string) (map[string]map[string]string, error) {
+	tags := make(map[string]map[string]string)
+	for start := 0; start < len(names); start += 20 {
+		end := start + 20
+		if end > len(names) {
+			end = len(names)
+		}
+
+		var out *elb.DescribeTagsOutput
+		err := d.retry(ctx, ServiceELB, func() (err error) {
+			out, err = elbSvc.DescribeTagsWithContext(ctx, &elb.DescribeTagsInput{
+				LoadBalancerNames: names[start:end],
+			})
+			return
+		})
+		if err != nil {
+			return tags, err
+		}
+
+		for _, desc := range out.TagDescriptions {
+			for _, t := range desc.Tags {
+				name := aws.StringValue(desc.LoadBalancerName)
+				if tags[name] == nil {
+					tags[name] = make(map[string]string)
+				}
+				tags[name][aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+			}
+		}
+	}
+
+	return tags, nil
+}
@@ -1,9 +1,11 @@
 package awsdata_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/sirupsen/logrus"
@@ -25,6 +27,9 @@ var testELBRows = []inventory.Row{
 		Function:              "mydomain.com",
 		SerialAssetTagNumber:  "arn:aws:elasticloadbalancing:us-east-1:012345678910:loadbalancer/abcdefgh12345678",
 		VLANNetworkID:         "vpc-abcdefgh",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier: "12345678abcdefgh",
@@ -88,7 +93,7 @@ type ELBMock struct {
 	elbiface.ELBAPI
 }
 
-func (e ELBMock) DescribeLoadBalancers(cfg *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+func (e ELBMock) DescribeLoadBalancersWithContext(ctx aws.Context, cfg *elb.DescribeLoadBalancersInput, opts ...request.Option) (*elb.DescribeLoadBalancersOutput, error) {
 	if cfg.Marker == nil {
 		return testELBDescribeLoadBalancersOutputPage1, nil
 	}
@@ -96,11 +101,30 @@ func (e ELBMock) DescribeLoadBalancers(cfg *elb.DescribeLoadBalancersInput) (*el
 	return testELBDescribeLoadBalancersOutputPage2, nil
 }
 
+func (e ELBMock) DescribeTagsWithContext(ctx aws.Context, cfg *elb.DescribeTagsInput, opts ...request.Option) (*elb.DescribeTagsOutput, error) {
+	out := &elb.DescribeTagsOutput{}
+	for _, name := range cfg.LoadBalancerNames {
+		if aws.StringValue(name) == testELBRows[0].UniqueAssetIdentifier {
+			out.TagDescriptions = append(out.TagDescriptions, &elb.TagDescription{
+				LoadBalancerName: name,
+				Tags: []*elb.Tag{
+					{
+						Key:   aws.String("Owner"),
+						Value: aws.String("test owner"),
+					},
+				},
+			})
+		}
+	}
+
+	return out, nil
+}
+
 type ELBErrorMock struct {
 	elbiface.ELBAPI
 }
 
-func (e ELBErrorMock) DescribeLoadBalancers(cfg *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+func (e ELBErrorMock) DescribeLoadBalancersWithContext(ctx aws.Context, cfg *elb.DescribeLoadBalancersInput, opts ...request.Option) (*elb.DescribeLoadBalancersOutput, error) {
 	return &elb.DescribeLoadBalancersOutput{}, testError
 }
 
@@ -109,7 +133,7 @@ func TestCanLoadELBs(t *testing.T) {
 	d := New(logrus.New(), TestClients{EC2: EC2Mock{}, ELB: ELBMock{}})
 
 	var count int
-	d.Load([]string{DefaultRegion}, []string{ServiceELB}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceELB}, func(row inventory.Row) error {
 		require.Equal(t, testELBRows[count], row)
 		count++
 		return nil
@@ -122,7 +146,7 @@ func TestLoadELBsLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{EC2: EC2ErrorMock{}, ELB: ELBErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceELB}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceELB}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
@@ -1,10 +1,13 @@
 package awsdata
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/manywho/awsinventory/internal/inventory"
 	"github.com/sirupsen/logrus"
 )
@@ -23,9 +26,13 @@ const (
 	ServiceELBV2 string = "elbv2"
 )
 
-func (d *AWSData) loadELBV2s(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceELBV2, elbv2.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadELBV2s(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadELBV2s(ctx context.Context, region string) {
 	elbv2Svc := d.clients.GetELBV2Client(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -39,10 +46,13 @@ func (d *AWSData) loadELBV2s(region string) {
 	done := false
 	params := &elbv2.DescribeLoadBalancersInput{}
 	for !done {
-		out, err := elbv2Svc.DescribeLoadBalancers(params)
-
+		var out *elbv2.DescribeLoadBalancersOutput
+		err := d.retry(ctx, ServiceELBV2, func() (err error) {
+			out, err = elbv2Svc.DescribeLoadBalancersWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to describe load balancers: %s", err)
+			d.AddError(ServiceELBV2, region, fmt.Errorf("failed to describe load balancers: %w", err))
 			return
 		}
 
@@ -57,6 +67,16 @@ func (d *AWSData) loadELBV2s(region string) {
 
 	log.Info("processing data")
 
+	var arns []*string
+	for _, l := range loadBalancers {
+		arns = append(arns, l.LoadBalancerArn)
+	}
+
+	tags, err := d.describeELBV2Tags(ctx, elbv2Svc, arns)
+	if err != nil {
+		d.AddError(ServiceELBV2, region, fmt.Errorf("failed to describe tags: %w", err))
+	}
+
 	for _, l := range loadBalancers {
 		var assettype string
 		if aws.StringValue(l.Type) == "application" {
@@ -89,7 +109,7 @@ func (d *AWSData) loadELBV2s(region string) {
 			}
 		}
 
-		d.rows <- inventory.Row{
+		d.AddRow(ServiceELBV2, region, inventory.Row{
 			UniqueAssetIdentifier: aws.StringValue(l.LoadBalancerName),
 			IPv4orIPv6Address:     strings.Join(ips, "\n"),
 			Virtual:               true,
@@ -99,8 +119,44 @@ func (d *AWSData) loadELBV2s(region string) {
 			AssetType:             assettype,
 			SerialAssetTagNumber:  aws.StringValue(l.LoadBalancerArn),
 			VLANNetworkID:         aws.StringValue(l.VpcId),
-		}
+			Tags:                  tags[aws.StringValue(l.LoadBalancerArn)],
+		})
 	}
 
 	log.Info("finished processing data")
 }
+
+// describeELBV2Tags returns the tags of each of the load balancers which have any, keyed by ARN.
+// DescribeTags takes up to 20 load balancers at a time.
+func (d *AWSData) describeELBV2Tags(ctx context.Context, elbv2Svc elbv2iface.ELBV2API, arns []*string) (map[string]map[string]string, error) {
+	tags := make(map[string]map[string]string)
+	for start := 0; start < len(arns); start += 20 {
+		end := start + 20
+		if end > len(arns) {
+			end = len(arns)
+		}
+
+		var out *elbv2.DescribeTagsOutput
+		err := d.retry(ctx, ServiceELBV2, func() (err error) {
+			out, err = elbv2Svc.DescribeTagsWithContext(ctx, &elbv2.DescribeTagsInput{
+				ResourceArns: arns[start:end],
+			})
+			return
+		})
+		if err != nil {
+			return tags, err
+		}
+
+		for _, desc := range out.TagDescriptions {
+			for _, t := range desc.Tags {
+				arn := aws.StringValue(desc.ResourceArn)
+				if tags[arn] == nil {
+					tags[arn] = make(map[string]string)
+				}
+				tags[arn][aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+			}
+		}
+	}
+
+	return tags, nil
+}
@@ -1,10 +1,12 @@
 package awsdata_test
 
 import (
+	"context"
 	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/sirupsen/logrus"
@@ -26,6 +28,9 @@ var testELBV2Rows = []inventory.Row{
 		AssetType:             AssetTypeALB,
 		SerialAssetTagNumber:  "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/12345678abcdefgh/50dc6c495c0c9188",
 		VLANNetworkID:         "vpc-abcdefgh",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier: "a1b2c3d4e5f6g7h8",
@@ -149,7 +154,7 @@ type ELBV2Mock struct {
 	elbv2iface.ELBV2API
 }
 
-func (e ELBV2Mock) DescribeLoadBalancers(cfg *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+func (e ELBV2Mock) DescribeLoadBalancersWithContext(ctx aws.Context, cfg *elbv2.DescribeLoadBalancersInput, opts ...request.Option) (*elbv2.DescribeLoadBalancersOutput, error) {
 	if cfg.Marker == nil {
 		return testELBV2DescribeLoadBalancersOutputPage1, nil
 	}
@@ -157,11 +162,30 @@ func (e ELBV2Mock) DescribeLoadBalancers(cfg *elbv2.DescribeLoadBalancersInput)
 	return testELBV2DescribeLoadBalancersOutputPage2, nil
 }
 
+func (e ELBV2Mock) DescribeTagsWithContext(ctx aws.Context, cfg *elbv2.DescribeTagsInput, opts ...request.Option) (*elbv2.DescribeTagsOutput, error) {
+	out := &elbv2.DescribeTagsOutput{}
+	for _, arn := range cfg.ResourceArns {
+		if aws.StringValue(arn) == testELBV2Rows[0].SerialAssetTagNumber {
+			out.TagDescriptions = append(out.TagDescriptions, &elbv2.TagDescription{
+				ResourceArn: arn,
+				Tags: []*elbv2.Tag{
+					{
+						Key:   aws.String("Owner"),
+						Value: aws.String("test owner"),
+					},
+				},
+			})
+		}
+	}
+
+	return out, nil
+}
+
 type ELBV2ErrorMock struct {
 	elbv2iface.ELBV2API
 }
 
-func (e ELBV2ErrorMock) DescribeLoadBalancers(cfg *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+func (e ELBV2ErrorMock) DescribeLoadBalancersWithContext(ctx aws.Context, cfg *elbv2.DescribeLoadBalancersInput, opts ...request.Option) (*elbv2.DescribeLoadBalancersOutput, error) {
 	return &elbv2.DescribeLoadBalancersOutput{}, testError
 }
 
@@ -170,7 +194,7 @@ func TestCanLoadELBV2s(t *testing.T) {
 	d := New(logrus.New(), TestClients{ELBV2: ELBV2Mock{}})
 
 	var rows []inventory.Row
-	d.Load([]string{DefaultRegion}, []string{ServiceELBV2}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceELBV2}, func(row inventory.Row) error {
 		rows = append(rows, row)
 		return nil
 	})
@@ -191,7 +215,7 @@ func TestLoadELBV2sLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{ELBV2: ELBV2ErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceELBV2}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceELBV2}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
@@ -0,0 +1,127 @@
+package awsdata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/manywho/awsinventory/internal/inventory"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AssetTypeNetworkInterface is the value used in the AssetType field when fetching network interfaces
+	AssetTypeNetworkInterface string = "Network Interface"
+
+	// ServiceENI is the key for the network interfaces service
+	ServiceENI string = "eni"
+)
+
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceENI, ec2.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadNetworkInterfaces(ctx, region)
+	}))
+}
+
+// loadNetworkInterfaces loads the network interfaces in the region. They come from the EC2 API, so their requests
+// are retried and limited as EC2 requests.
+func (d *AWSData) loadNetworkInterfaces(ctx context.Context, region string) {
+	ec2Svc := d.clients.GetEC2Client(region)
+
+	log := d.log.WithFields(logrus.Fields{
+		"region":  region,
+		"service": ServiceENI,
+	})
+
+	log.Info("loading data")
+
+	var partition string
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		partition = p.ID()
+	}
+
+	var networkInterfaces []*ec2.NetworkInterface
+	done := false
+	params := &ec2.DescribeNetworkInterfacesInput{}
+	for !done {
+		var out *ec2.DescribeNetworkInterfacesOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeNetworkInterfacesWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceENI, region, fmt.Errorf("failed to describe network interfaces: %w", err))
+			return
+		}
+
+		networkInterfaces = append(networkInterfaces, out.NetworkInterfaces...)
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	log.Info("processing data")
+
+	for _, ni := range networkInterfaces {
+		var public bool
+		var ips []string
+		for _, a := range ni.PrivateIpAddresses {
+			ips = append(ips, aws.StringValue(a.PrivateIpAddress))
+			if a.Association != nil && aws.StringValue(a.Association.PublicIp) != "" {
+				ips = append(ips, aws.StringValue(a.Association.PublicIp))
+				public = true
+			}
+		}
+		for _, a := range ni.Ipv6Addresses {
+			ips = append(ips, aws.StringValue(a.Ipv6Address))
+		}
+
+		comments := "Not attached"
+		if owner := networkInterfaceOwner(ni); owner != "" {
+			comments = "Attached to: " + owner
+		}
+
+		d.AddRow(ServiceENI, region, inventory.Row{
+			UniqueAssetIdentifier: aws.StringValue(ni.NetworkInterfaceId),
+			IPv4orIPv6Address:     strings.Join(ips, "\n"),
+			Virtual:               true,
+			Public:                public,
+			MACAddress:            aws.StringValue(ni.MacAddress),
+			Location:              region,
+			AssetType:             AssetTypeNetworkInterface,
+			Function:              ec2Name(ni.TagSet),
+			Comments:              comments,
+			SerialAssetTagNumber:  ec2ARN(partition, region, aws.StringValue(ni.OwnerId), "network-interface", aws.StringValue(ni.NetworkInterfaceId)),
+			VLANNetworkID:         aws.StringValue(ni.VpcId),
+			Tags:                  ec2Tags(ni.TagSet),
+		})
+	}
+
+	log.Info("finished processing data")
+}
+
+// networkInterfaceOwner returns what a network interface is attached to, either an EC2 instance or, for interfaces
+// created by services such as Lambda, RDS, ELB and VPC endpoints, the description the service gave it, or the ID
+// of the service when there is no description.
+// It returns an empty string when the interface is not attached to anything.
+func networkInterfaceOwner(ni *ec2.NetworkInterface) string {
+	if ni.Attachment == nil && !aws.BoolValue(ni.RequesterManaged) {
+		return ""
+	}
+
+	if ni.Attachment != nil && aws.StringValue(ni.Attachment.InstanceId) != "" {
+		return "EC2 instance " + aws.StringValue(ni.Attachment.InstanceId)
+	}
+
+	if aws.StringValue(ni.Description) != "" {
+		return aws.StringValue(ni.Description)
+	}
+
+	return aws.StringValue(ni.RequesterId)
+}
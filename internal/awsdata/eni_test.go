@@ -0,0 +1,250 @@
+package awsdata_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+var testNetworkInterfaceRows = []inventory.Row{
+	{
+		UniqueAssetIdentifier: "eni-11111111",
+		IPv4orIPv6Address:     "10.0.1.10\n203.0.113.20",
+		Virtual:               true,
+		Public:                true,
+		MACAddress:            "0a:1b:2c:3d:4e:51",
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeNetworkInterface,
+		Function:              "test app 1",
+		Comments:              "Attached to: EC2 instance i-1234567890abcdef0",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:network-interface/eni-11111111",
+		VLANNetworkID:         "vpc-12345678",
+		Tags:                  map[string]string{"Name": "test app 1"},
+	},
+	{
+		UniqueAssetIdentifier: "eni-22222222",
+		IPv4orIPv6Address:     "10.0.2.20",
+		Virtual:               true,
+		MACAddress:            "0a:1b:2c:3d:4e:52",
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeNetworkInterface,
+		Comments:              "Attached to: AWS Lambda VPC ENI-test-function-1a2b3c4d",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:network-interface/eni-22222222",
+		VLANNetworkID:         "vpc-12345678",
+	},
+	{
+		UniqueAssetIdentifier: "eni-33333333",
+		IPv4orIPv6Address:     "10.0.3.30\n2600:1f18:abcd:1203::30",
+		Virtual:               true,
+		MACAddress:            "0a:1b:2c:3d:4e:53",
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeNetworkInterface,
+		Comments:              "Attached to: RDSNetworkInterface",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:network-interface/eni-33333333",
+		VLANNetworkID:         "vpc-12345678",
+	},
+	{
+		UniqueAssetIdentifier: "eni-44444444",
+		IPv4orIPv6Address:     "10.0.4.40",
+		Virtual:               true,
+		MACAddress:            "0a:1b:2c:3d:4e:54",
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeNetworkInterface,
+		Comments:              "Not attached",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:network-interface/eni-44444444",
+		VLANNetworkID:         "vpc-12345678",
+	},
+	{
+		UniqueAssetIdentifier: "eni-55555555",
+		IPv4orIPv6Address:     "10.0.1.25\n203.0.113.10",
+		Virtual:               true,
+		Public:                true,
+		MACAddress:            "0a:1b:2c:3d:4e:55",
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeNetworkInterface,
+		Comments:              "Attached to: Interface for NAT Gateway nat-0123456789abcdef0",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:network-interface/eni-55555555",
+		VLANNetworkID:         "vpc-12345678",
+	},
+}
+
+// Test Data
+var testEC2DescribeNetworkInterfacesOutputPage1 = &ec2.DescribeNetworkInterfacesOutput{
+	NextToken: aws.String("eni-33333333"),
+	NetworkInterfaces: []*ec2.NetworkInterface{
+		{
+			NetworkInterfaceId: aws.String("eni-11111111"),
+			Description:        aws.String("Primary network interface"),
+			InterfaceType:      aws.String(ec2.NetworkInterfaceTypeInterface),
+			MacAddress:         aws.String(testNetworkInterfaceRows[0].MACAddress),
+			OwnerId:            aws.String("123456789012"),
+			VpcId:              aws.String("vpc-12345678"),
+			Attachment: &ec2.NetworkInterfaceAttachment{
+				InstanceId:      aws.String("i-1234567890abcdef0"),
+				InstanceOwnerId: aws.String("123456789012"),
+			},
+			PrivateIpAddresses: []*ec2.NetworkInterfacePrivateIpAddress{
+				{
+					PrivateIpAddress: aws.String("10.0.1.10"),
+					Association: &ec2.NetworkInterfaceAssociation{
+						PublicIp: aws.String("203.0.113.20"),
+					},
+				},
+			},
+			TagSet: []*ec2.Tag{
+				{
+					Key:   aws.String("Name"),
+					Value: aws.String("test app 1"),
+				},
+			},
+		},
+		{
+			NetworkInterfaceId: aws.String("eni-22222222"),
+			Description:        aws.String("AWS Lambda VPC ENI-test-function-1a2b3c4d"),
+			InterfaceType:      aws.String("lambda"),
+			MacAddress:         aws.String(testNetworkInterfaceRows[1].MACAddress),
+			OwnerId:            aws.String("123456789012"),
+			RequesterId:        aws.String("123456789012:test-function"),
+			RequesterManaged:   aws.Bool(false),
+			VpcId:              aws.String("vpc-12345678"),
+			Attachment: &ec2.NetworkInterfaceAttachment{
+				InstanceOwnerId: aws.String("amazon-aws"),
+			},
+			PrivateIpAddresses: []*ec2.NetworkInterfacePrivateIpAddress{
+				{
+					PrivateIpAddress: aws.String("10.0.2.20"),
+				},
+			},
+		},
+	},
+}
+
+var testEC2DescribeNetworkInterfacesOutputPage2 = &ec2.DescribeNetworkInterfacesOutput{
+	NetworkInterfaces: []*ec2.NetworkInterface{
+		{
+			NetworkInterfaceId: aws.String("eni-33333333"),
+			Description:        aws.String("RDSNetworkInterface"),
+			InterfaceType:      aws.String(ec2.NetworkInterfaceTypeInterface),
+			MacAddress:         aws.String(testNetworkInterfaceRows[2].MACAddress),
+			OwnerId:            aws.String("123456789012"),
+			RequesterId:        aws.String("amazon-rds"),
+			RequesterManaged:   aws.Bool(true),
+			VpcId:              aws.String("vpc-12345678"),
+			Attachment: &ec2.NetworkInterfaceAttachment{
+				InstanceOwnerId: aws.String("amazon-rds"),
+			},
+			PrivateIpAddresses: []*ec2.NetworkInterfacePrivateIpAddress{
+				{
+					PrivateIpAddress: aws.String("10.0.3.30"),
+				},
+			},
+			Ipv6Addresses: []*ec2.NetworkInterfaceIpv6Address{
+				{
+					Ipv6Address: aws.String("2600:1f18:abcd:1203::30"),
+				},
+			},
+		},
+		{
+			NetworkInterfaceId: aws.String("eni-44444444"),
+			Description:        aws.String("spare interface"),
+			InterfaceType:      aws.String(ec2.NetworkInterfaceTypeInterface),
+			MacAddress:         aws.String(testNetworkInterfaceRows[3].MACAddress),
+			OwnerId:            aws.String("123456789012"),
+			RequesterId:        aws.String("AIDAEXAMPLE"),
+			RequesterManaged:   aws.Bool(false),
+			Status:             aws.String(ec2.NetworkInterfaceStatusAvailable),
+			VpcId:              aws.String("vpc-12345678"),
+			PrivateIpAddresses: []*ec2.NetworkInterfacePrivateIpAddress{
+				{
+					PrivateIpAddress: aws.String("10.0.4.40"),
+				},
+			},
+		},
+		{
+			NetworkInterfaceId: aws.String("eni-55555555"),
+			Description:        aws.String("Interface for NAT Gateway nat-0123456789abcdef0"),
+			InterfaceType:      aws.String(ec2.NetworkInterfaceTypeNatGateway),
+			MacAddress:         aws.String(testNetworkInterfaceRows[4].MACAddress),
+			OwnerId:            aws.String("123456789012"),
+			RequesterId:        aws.String("AIDAEXAMPLE"),
+			RequesterManaged:   aws.Bool(true),
+			VpcId:              aws.String("vpc-12345678"),
+			PrivateIpAddresses: []*ec2.NetworkInterfacePrivateIpAddress{
+				{
+					PrivateIpAddress: aws.String("10.0.1.25"),
+					Association: &ec2.NetworkInterfaceAssociation{
+						PublicIp: aws.String("203.0.113.10"),
+					},
+				},
+			},
+		},
+	},
+}
+
+// Mocks
+type ENIMock struct {
+	ec2iface.EC2API
+}
+
+// DescribeNetworkInterfacesWithContext lists every test network interface when no IDs are given, or those matching
+// the IDs given
+func (e ENIMock) DescribeNetworkInterfacesWithContext(ctx aws.Context, cfg *ec2.DescribeNetworkInterfacesInput, opts ...request.Option) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	if len(cfg.NetworkInterfaceIds) == 0 {
+		if cfg.NextToken == nil {
+			return testEC2DescribeNetworkInterfacesOutputPage1, nil
+		}
+
+		return testEC2DescribeNetworkInterfacesOutputPage2, nil
+	}
+
+	out := &ec2.DescribeNetworkInterfacesOutput{}
+	for _, page := range []*ec2.DescribeNetworkInterfacesOutput{testEC2DescribeNetworkInterfacesOutputPage1, testEC2DescribeNetworkInterfacesOutputPage2} {
+		for _, ni := range page.NetworkInterfaces {
+			for _, id := range cfg.NetworkInterfaceIds {
+				if aws.StringValue(id) == aws.StringValue(ni.NetworkInterfaceId) {
+					out.NetworkInterfaces = append(out.NetworkInterfaces, ni)
+				}
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// Tests
+func TestCanLoadNetworkInterfaces(t *testing.T) {
+	d := New(logrus.New(), TestClients{EC2: ENIMock{}})
+
+	var rows []inventory.Row
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceENI}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	require.Equal(t, testNetworkInterfaceRows, rows)
+}
+
+func TestLoadNetworkInterfacesLogsError(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EC2ErrorMock{}})
+
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceENI}, nil)
+
+	assertTestErrorWasLogged(t, hook.Entries)
+}
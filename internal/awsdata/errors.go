@@ -11,12 +11,31 @@ var (
 
 	// ErrNoServices is logged when no services are given to the Load method
 	ErrNoServices = errors.New("no services specified")
+
+	// ErrInvalidRegion is wrapped by the error logged when an unknown region is given to the Load method
+	ErrInvalidRegion = errors.New("invalid region")
+
+	// ErrInvalidService is wrapped by the error logged when an unknown service is given to the Load method
+	ErrInvalidService = errors.New("invalid service")
+
+	// ErrInvalidTagMapping is wrapped by the error logged when a tag is mapped to an unknown field
+	ErrInvalidTagMapping = errors.New("invalid tag mapping")
+
+	// ErrInvalidFilter is wrapped by the error logged when a resource or tag filter has a malformed pattern
+	ErrInvalidFilter = errors.New("invalid filter")
+
+	// ErrInvalidEndpoint is wrapped by the error returned when an endpoint is given for an unknown service
+	ErrInvalidEndpoint = errors.New("invalid endpoint")
 )
 
 func newErrInvalidRegion(region string) error {
-	return fmt.Errorf("invalid region: %s", region)
+	return fmt.Errorf("%w: %s", ErrInvalidRegion, region)
 }
 
 func newErrInvalidService(service string) error {
-	return fmt.Errorf("invalid service: %s", service)
+	return fmt.Errorf("%w: %s", ErrInvalidService, service)
+}
+
+func newErrInvalidEndpoint(service string) error {
+	return fmt.Errorf("%w: unknown service %s", ErrInvalidEndpoint, service)
 }
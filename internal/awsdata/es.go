@@ -1,6 +1,7 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -17,9 +18,13 @@ const (
 	ServiceElasticsearchService string = "es"
 )
 
-func (d *AWSData) loadElasticsearchDomains(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceElasticsearchService, elasticsearchservice.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadElasticsearchDomains(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadElasticsearchDomains(ctx context.Context, region string) {
 	elasticsearchserviceSvc := d.clients.GetElasticsearchServiceClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -29,9 +34,13 @@ func (d *AWSData) loadElasticsearchDomains(region string) {
 
 	log.Info("loading data")
 
-	out, err := elasticsearchserviceSvc.ListDomainNames(&elasticsearchservice.ListDomainNamesInput{})
+	var out *elasticsearchservice.ListDomainNamesOutput
+	err := d.retry(ctx, ServiceElasticsearchService, func() (err error) {
+		out, err = elasticsearchserviceSvc.ListDomainNamesWithContext(ctx, &elasticsearchservice.ListDomainNamesInput{})
+		return
+	})
 	if err != nil {
-		log.Errorf("failed to list domain names: %s", err)
+		d.AddError(ServiceElasticsearchService, region, fmt.Errorf("failed to list domain names: %w", err))
 		return
 	}
 
@@ -54,15 +63,33 @@ func (d *AWSData) loadElasticsearchDomains(region string) {
 			j = len(domains)
 		}
 
-		out, err := elasticsearchserviceSvc.DescribeElasticsearchDomains(&elasticsearchservice.DescribeElasticsearchDomainsInput{
-			DomainNames: aws.StringSlice(domains[i:j]),
+		var out *elasticsearchservice.DescribeElasticsearchDomainsOutput
+		err := d.retry(ctx, ServiceElasticsearchService, func() (err error) {
+			out, err = elasticsearchserviceSvc.DescribeElasticsearchDomainsWithContext(ctx, &elasticsearchservice.DescribeElasticsearchDomainsInput{
+				DomainNames: aws.StringSlice(domains[i:j]),
+			})
+			return
 		})
 		if err != nil {
-			log.Errorf("failed to describe elasticsearch domains: %s", err)
+			d.AddError(ServiceElasticsearchService, region, fmt.Errorf("failed to describe elasticsearch domains: %w", err))
 			continue
 		}
 		for _, c := range out.DomainStatusList {
-			d.rows <- inventory.Row{
+			var tags map[string]string
+			var outTags *elasticsearchservice.ListTagsOutput
+			err := d.retry(ctx, ServiceElasticsearchService, func() (err error) {
+				outTags, err = elasticsearchserviceSvc.ListTagsWithContext(ctx, &elasticsearchservice.ListTagsInput{
+					ARN: c.ARN,
+				})
+				return
+			})
+			if err != nil {
+				d.AddError(ServiceElasticsearchService, region, fmt.Errorf("failed to list tags for %s: %w", aws.StringValue(c.DomainName), err))
+			} else {
+				tags = tagMap(len(outTags.TagList), func(i int) (*string, *string) { return outTags.TagList[i].Key, outTags.TagList[i].Value })
+			}
+
+			d.AddRow(ServiceElasticsearchService, region, inventory.Row{
 				UniqueAssetIdentifier:          aws.StringValue(c.DomainName),
 				Virtual:                        true,
 				Public:                         false,
@@ -74,7 +101,8 @@ func (d *AWSData) loadElasticsearchDomains(region string) {
 				SoftwareDatabaseNameAndVersion: fmt.Sprintf("Elasticsearch %s", aws.StringValue(c.ElasticsearchVersion)),
 				SerialAssetTagNumber:           aws.StringValue(c.ARN),
 				VLANNetworkID:                  aws.StringValue(c.VPCOptions.VPCId),
-			}
+				Tags:                           tags,
+			})
 		}
 	}
 
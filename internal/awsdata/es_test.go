@@ -1,9 +1,11 @@
 package awsdata_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/elasticsearchservice"
 	"github.com/aws/aws-sdk-go/service/elasticsearchservice/elasticsearchserviceiface"
 	"github.com/sirupsen/logrus"
@@ -27,6 +29,9 @@ var testElasticsearchDomainRows = []inventory.Row{
 		SoftwareDatabaseNameAndVersion: "Elasticsearch 7.1",
 		SerialAssetTagNumber:           "arn:aws:es:us-east-2:123456789012:domain/test-domain-1",
 		VLANNetworkID:                  "vpc-12345678",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier:          "test-domain-2",
@@ -123,23 +128,38 @@ type ElasticsearchServiceMock struct {
 	elasticsearchserviceiface.ElasticsearchServiceAPI
 }
 
-func (e ElasticsearchServiceMock) ListDomainNames(cfg *elasticsearchservice.ListDomainNamesInput) (*elasticsearchservice.ListDomainNamesOutput, error) {
+func (e ElasticsearchServiceMock) ListDomainNamesWithContext(ctx aws.Context, cfg *elasticsearchservice.ListDomainNamesInput, opts ...request.Option) (*elasticsearchservice.ListDomainNamesOutput, error) {
 	return testElasticsearchListDomainNamesOutput, nil
 }
 
-func (e ElasticsearchServiceMock) DescribeElasticsearchDomains(cfg *elasticsearchservice.DescribeElasticsearchDomainsInput) (*elasticsearchservice.DescribeElasticsearchDomainsOutput, error) {
+func (e ElasticsearchServiceMock) DescribeElasticsearchDomainsWithContext(ctx aws.Context, cfg *elasticsearchservice.DescribeElasticsearchDomainsInput, opts ...request.Option) (*elasticsearchservice.DescribeElasticsearchDomainsOutput, error) {
 	return testElasticsearchDescribeElasticsearchDomainsOutput, nil
 }
 
+func (e ElasticsearchServiceMock) ListTagsWithContext(ctx aws.Context, cfg *elasticsearchservice.ListTagsInput, opts ...request.Option) (*elasticsearchservice.ListTagsOutput, error) {
+	if aws.StringValue(cfg.ARN) != testElasticsearchDomainRows[0].SerialAssetTagNumber {
+		return &elasticsearchservice.ListTagsOutput{}, nil
+	}
+
+	return &elasticsearchservice.ListTagsOutput{
+		TagList: []*elasticsearchservice.Tag{
+			{
+				Key:   aws.String("Owner"),
+				Value: aws.String("test owner"),
+			},
+		},
+	}, nil
+}
+
 type ElasticsearchServiceErrorMock struct {
 	elasticsearchserviceiface.ElasticsearchServiceAPI
 }
 
-func (e ElasticsearchServiceErrorMock) ListDomainNames(cfg *elasticsearchservice.ListDomainNamesInput) (*elasticsearchservice.ListDomainNamesOutput, error) {
+func (e ElasticsearchServiceErrorMock) ListDomainNamesWithContext(ctx aws.Context, cfg *elasticsearchservice.ListDomainNamesInput, opts ...request.Option) (*elasticsearchservice.ListDomainNamesOutput, error) {
 	return &elasticsearchservice.ListDomainNamesOutput{}, testError
 }
 
-func (e ElasticsearchServiceErrorMock) DescribeElasticsearchDomains(cfg *elasticsearchservice.DescribeElasticsearchDomainsInput) (*elasticsearchservice.DescribeElasticsearchDomainsOutput, error) {
+func (e ElasticsearchServiceErrorMock) DescribeElasticsearchDomainsWithContext(ctx aws.Context, cfg *elasticsearchservice.DescribeElasticsearchDomainsInput, opts ...request.Option) (*elasticsearchservice.DescribeElasticsearchDomainsOutput, error) {
 	return &elasticsearchservice.DescribeElasticsearchDomainsOutput{}, testError
 }
 
@@ -148,7 +168,7 @@ func TestCanLoadElasticsearchDomains(t *testing.T) {
 	d := New(logrus.New(), TestClients{ElasticsearchService: ElasticsearchServiceMock{}})
 
 	var count int
-	d.Load([]string{DefaultRegion}, []string{ServiceElasticsearchService}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceElasticsearchService}, func(row inventory.Row) error {
 		require.Equal(t, testElasticsearchDomainRows[count], row)
 		count++
 		return nil
@@ -161,7 +181,7 @@ func TestLoadElasticsearchDomainsLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{ElasticsearchService: ElasticsearchServiceErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceElasticsearchService}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceElasticsearchService}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
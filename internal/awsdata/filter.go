@@ -0,0 +1,177 @@
+package awsdata
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+// filters holds the resource and tag value patterns of an AWSData, compiled once by compileFilters
+type filters struct {
+	includeTags      map[string]*regexp.Regexp
+	excludeTags      map[string]*regexp.Regexp
+	includeResources []*regexp.Regexp
+	excludeResources []*regexp.Regexp
+}
+
+// compileFilters compiles the resource and tag value patterns for keepRow, returning an error when one is malformed
+func (d *AWSData) compileFilters() error {
+	f := filters{
+		includeTags: make(map[string]*regexp.Regexp),
+		excludeTags: make(map[string]*regexp.Regexp),
+	}
+
+	for key, pattern := range d.includeTags {
+		re, err := compileFilter(pattern)
+		if err != nil {
+			return err
+		}
+		f.includeTags[key] = re
+	}
+
+	for key, pattern := range d.excludeTags {
+		re, err := compileFilter(pattern)
+		if err != nil {
+			return err
+		}
+		f.excludeTags[key] = re
+	}
+
+	for _, pattern := range d.includeResources {
+		re, err := compileFilter(pattern)
+		if err != nil {
+			return err
+		}
+		f.includeResources = append(f.includeResources, re)
+	}
+
+	for _, pattern := range d.excludeResources {
+		re, err := compileFilter(pattern)
+		if err != nil {
+			return err
+		}
+		f.excludeResources = append(f.excludeResources, re)
+	}
+
+	d.filters = f
+
+	return nil
+}
+
+// compileFilter compiles a single resource or tag value pattern, wrapping ErrInvalidFilter when it is malformed
+func compileFilter(pattern string) (*regexp.Regexp, error) {
+	re, err := globRegexp(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrInvalidFilter, pattern, err)
+	}
+
+	return re, nil
+}
+
+// keepRow returns true when the row passes the resource and tag filters. A row is kept when it has every
+// included tag, matches any included resource pattern, and has none of the excluded tags or resource patterns.
+func (d *AWSData) keepRow(row inventory.Row) bool {
+	for key, re := range d.filters.includeTags {
+		if !tagMatches(row.Tags, key, re) {
+			return false
+		}
+	}
+
+	for key, re := range d.filters.excludeTags {
+		if tagMatches(row.Tags, key, re) {
+			return false
+		}
+	}
+
+	if len(d.filters.includeResources) > 0 && !resourceMatches(row, d.filters.includeResources) {
+		return false
+	}
+
+	return !resourceMatches(row, d.filters.excludeResources)
+}
+
+// tagMatches returns true when the tags have the key with a value matching the pattern
+func tagMatches(tags map[string]string, key string, re *regexp.Regexp) bool {
+	value, ok := tags[key]
+	if !ok {
+		return false
+	}
+
+	return re.MatchString(value)
+}
+
+// resourceMatches returns true when the row's unique asset identifier or serial number, usually an ARN,
+// matches any of the patterns
+func resourceMatches(row inventory.Row, patterns []*regexp.Regexp) bool {
+	for _, re := range patterns {
+		for _, s := range []string{row.UniqueAssetIdentifier, row.SerialAssetTagNumber} {
+			if s != "" && re.MatchString(s) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// globRegexp compiles a glob pattern into an anchored regular expression. Unlike path.Match, * matches any
+// sequence of characters including /, so that patterns such as arn:aws:ecs:* match ARNs with paths.
+// ? matches any single character, [...] and [^...] match character classes, and \ escapes the next character.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^(?s:")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '\\':
+			i++
+			if i == len(runes) {
+				return nil, errors.New("pattern ends with an escape")
+			}
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case '[':
+			end := i + 1
+			if end < len(runes) && runes[end] == '^' {
+				end++
+			}
+			for end < len(runes) && runes[end] != ']' {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) || end == i+1 || (runes[i+1] == '^' && end == i+2) {
+				return nil, errors.New("malformed character class")
+			}
+
+			b.WriteByte('[')
+			for j := i + 1; j < end; j++ {
+				switch r := runes[j]; {
+				case r == '^' && j == i+1, r == '-':
+					b.WriteRune(r)
+				case r == '\\':
+					j++
+					b.WriteString(regexp.QuoteMeta(string(runes[j])))
+				default:
+					b.WriteString(regexp.QuoteMeta(string(r)))
+				}
+			}
+			b.WriteByte(']')
+			i = end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString(")$")
+
+	return regexp.Compile(b.String())
+}
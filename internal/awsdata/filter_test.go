@@ -0,0 +1,87 @@
+package awsdata_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+func loadFilteredEC2Instances(t *testing.T, opts ...Option) ([]inventory.Row, *Report) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EC2Mock{}, Route53: EC2Route53Mock{}}, opts...)
+
+	var rows []inventory.Row
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEC2}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	return rows, report
+}
+
+// Tests
+func TestLoadKeepsRowsWithIncludedTags(t *testing.T) {
+	rows, report := loadFilteredEC2Instances(t, WithIncludeTag("Name", "test app 1"), WithIncludeTag("extra tag", "*"))
+
+	require.Equal(t, []inventory.Row{testEC2InstanceRows[0]}, rows)
+	require.Equal(t, 2, report.Filtered[ServiceEC2][DefaultRegion])
+	require.Equal(t, 2, report.FilteredCount())
+	require.Equal(t, 1, report.Count())
+}
+
+func TestLoadLeavesOutRowsWithExcludedTags(t *testing.T) {
+	rows, report := loadFilteredEC2Instances(t, WithExcludeTag("Name", "test app [12]"))
+
+	require.Equal(t, []inventory.Row{testEC2InstanceRows[2]}, rows)
+	require.Equal(t, 2, report.FilteredCount())
+}
+
+func TestLoadKeepsRowsMatchingIncludedResources(t *testing.T) {
+	rows, report := loadFilteredEC2Instances(t, WithIncludeResource("i-1*"), WithIncludeResource("arn:aws:ec2:*:*:instance/i-2*"))
+
+	require.Equal(t, []inventory.Row{testEC2InstanceRows[0], testEC2InstanceRows[1]}, rows)
+	require.Equal(t, 1, report.FilteredCount())
+}
+
+func TestLoadLeavesOutRowsMatchingExcludedResources(t *testing.T) {
+	rows, report := loadFilteredEC2Instances(t, WithExcludeResource("i-3*"))
+
+	require.Equal(t, []inventory.Row{testEC2InstanceRows[0], testEC2InstanceRows[1]}, rows)
+	require.Equal(t, 1, report.FilteredCount())
+}
+
+func TestLoadMatchesResourcesAcrossSlashes(t *testing.T) {
+	rows, report := loadFilteredEC2Instances(t, WithIncludeResource("arn:aws:ec2:*"), WithExcludeResource("arn:*/i-3*"))
+
+	require.Equal(t, []inventory.Row{testEC2InstanceRows[0], testEC2InstanceRows[1]}, rows)
+	require.Equal(t, 1, report.FilteredCount())
+}
+
+func TestLoadMatchesResourcesWithCharacterClassesAndEscapes(t *testing.T) {
+	rows, _ := loadFilteredEC2Instances(t, WithIncludeResource("arn:aws:ec2:??-*:instance/i-[^2]*"), WithExcludeResource(`i-1\*`))
+
+	require.Equal(t, []inventory.Row{testEC2InstanceRows[0], testEC2InstanceRows[2]}, rows)
+}
+
+func TestLoadReportsErrInvalidFilter(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{}, WithIncludeResource("i-["))
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceEC2}, nil)
+
+	require.False(t, report.Complete())
+	require.True(t, errors.Is(report.Errors[0], ErrInvalidFilter))
+}
@@ -0,0 +1,137 @@
+package awsdata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/fsx"
+	"github.com/manywho/awsinventory/internal/inventory"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AssetTypeFSxFileSystem is the value used in the AssetType field when fetching FSx file systems
+	AssetTypeFSxFileSystem string = "FSx File System"
+
+	// ServiceFSx is the key for the FSx service
+	ServiceFSx string = "fsx"
+)
+
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceFSx, fsx.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadFSxFileSystems(ctx, region)
+	}))
+}
+
+func (d *AWSData) loadFSxFileSystems(ctx context.Context, region string) {
+	ec2Svc := d.clients.GetEC2Client(region)
+	fsxSvc := d.clients.GetFSxClient(region)
+
+	log := d.log.WithFields(logrus.Fields{
+		"region":  region,
+		"service": ServiceFSx,
+	})
+
+	log.Info("loading data")
+
+	var fileSystems []*fsx.FileSystem
+	done := false
+	params := &fsx.DescribeFileSystemsInput{}
+	for !done {
+		var out *fsx.DescribeFileSystemsOutput
+		err := d.retry(ctx, ServiceFSx, func() (err error) {
+			out, err = fsxSvc.DescribeFileSystemsWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceFSx, region, fmt.Errorf("failed to describe file systems: %w", err))
+			return
+		}
+
+		fileSystems = append(fileSystems, out.FileSystems...)
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	if len(fileSystems) == 0 {
+		log.Info("no data found; bailing early")
+		return
+	}
+
+	log.Info("processing data")
+
+	for _, fs := range fileSystems {
+		d.wg.Add(1)
+		go d.processFSxFileSystem(ctx, log, ec2Svc, fs, region)
+	}
+
+	log.Info("finished processing data")
+}
+
+func (d *AWSData) processFSxFileSystem(ctx context.Context, log *logrus.Entry, ec2Svc ec2iface.EC2API, fs *fsx.FileSystem, region string) {
+	defer d.wg.Done()
+
+	tags := tagMap(len(fs.Tags), func(i int) (*string, *string) { return fs.Tags[i].Key, fs.Tags[i].Value })
+
+	// Clients mount FSx file systems through their network interfaces, which hold their IP addresses.
+	// The interfaces themselves are left to the eni service, which lists every network interface.
+	var ips []string
+	if len(fs.NetworkInterfaceIds) > 0 {
+		var out *ec2.DescribeNetworkInterfacesOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeNetworkInterfacesWithContext(ctx, &ec2.DescribeNetworkInterfacesInput{
+				NetworkInterfaceIds: fs.NetworkInterfaceIds,
+			})
+			return
+		})
+		if err != nil {
+			log.Warningf("failed to describe network interfaces for %s: %s", aws.StringValue(fs.FileSystemId), err)
+		} else {
+			for _, ni := range out.NetworkInterfaces {
+				for _, a := range ni.PrivateIpAddresses {
+					ips = append(ips, aws.StringValue(a.PrivateIpAddress))
+				}
+			}
+		}
+	}
+
+	// FSx always encrypts file systems at rest, using the AWS managed key when no KmsKeyId is given
+	comments := []string{
+		fileSystemComments(aws.Int64Value(fs.StorageCapacity)*1024*1024*1024, true),
+		"Subnets: " + strings.Join(aws.StringValueSlice(fs.SubnetIds), ", "),
+	}
+
+	d.AddRow(ServiceFSx, region, inventory.Row{
+		UniqueAssetIdentifier: aws.StringValue(fs.FileSystemId),
+		IPv4orIPv6Address:     strings.Join(ips, "\n"),
+		Virtual:               true,
+		DNSNameOrURL:          aws.StringValue(fs.DNSName),
+		Location:              region,
+		AssetType:             AssetTypeFSxFileSystem,
+		HardwareMakeModel:     fmt.Sprintf("%s (%s)", aws.StringValue(fs.FileSystemType), aws.StringValue(fs.StorageType)),
+		Function:              fsxName(fs.Tags),
+		Comments:              strings.Join(comments, "\n"),
+		SerialAssetTagNumber:  aws.StringValue(fs.ResourceARN),
+		VLANNetworkID:         aws.StringValue(fs.VpcId),
+		Tags:                  tags,
+	})
+}
+
+// fsxName returns the value of the Name tag of an FSx file system, or an empty string when it has none
+func fsxName(tags []*fsx.Tag) string {
+	for _, t := range tags {
+		if aws.StringValue(t.Key) == "Name" {
+			return aws.StringValue(t.Value)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,190 @@
+package awsdata_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/fsx"
+	"github.com/aws/aws-sdk-go/service/fsx/fsxiface"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+var testFSxRows = []inventory.Row{
+	{
+		UniqueAssetIdentifier: "fs-0123456789abcdef0",
+		IPv4orIPv6Address:     "10.0.1.60\n10.0.2.60",
+		Virtual:               true,
+		DNSNameOrURL:          "amznfsxabcdefgh.corp.example.com",
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeFSxFileSystem,
+		HardwareMakeModel:     "WINDOWS (SSD)",
+		Function:              "test windows share",
+		Comments:              "1.2 TB, encrypted\nSubnets: subnet-12345678, subnet-23456789",
+		SerialAssetTagNumber:  "arn:aws:fsx:us-east-1:123456789012:file-system/fs-0123456789abcdef0",
+		VLANNetworkID:         "vpc-12345678",
+		Tags:                  map[string]string{"Name": "test windows share"},
+	},
+	{
+		UniqueAssetIdentifier: "fs-123456789abcdef01",
+		IPv4orIPv6Address:     "10.0.3.70",
+		Virtual:               true,
+		DNSNameOrURL:          "fs-123456789abcdef01.fsx.us-east-1.amazonaws.com",
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeFSxFileSystem,
+		HardwareMakeModel:     "LUSTRE (HDD)",
+		Comments:              "6.0 TB, encrypted\nSubnets: subnet-34567890",
+		SerialAssetTagNumber:  "arn:aws:fsx:us-east-1:123456789012:file-system/fs-123456789abcdef01",
+		VLANNetworkID:         "vpc-23456789",
+	},
+}
+
+// Test Data
+var testFSxDescribeFileSystemsOutputPage1 = &fsx.DescribeFileSystemsOutput{
+	FileSystems: []*fsx.FileSystem{
+		{
+			FileSystemId:        aws.String("fs-0123456789abcdef0"),
+			FileSystemType:      aws.String(fsx.FileSystemTypeWindows),
+			DNSName:             aws.String("amznfsxabcdefgh.corp.example.com"),
+			KmsKeyId:            aws.String("arn:aws:kms:us-east-1:123456789012:key/12345678-1234-1234-1234-123456789012"),
+			NetworkInterfaceIds: aws.StringSlice([]string{"eni-fsx11111", "eni-fsx22222"}),
+			ResourceARN:         aws.String("arn:aws:fsx:us-east-1:123456789012:file-system/fs-0123456789abcdef0"),
+			StorageCapacity:     aws.Int64(1200),
+			StorageType:         aws.String(fsx.StorageTypeSsd),
+			SubnetIds:           aws.StringSlice([]string{"subnet-12345678", "subnet-23456789"}),
+			VpcId:               aws.String("vpc-12345678"),
+			Tags: []*fsx.Tag{
+				{
+					Key:   aws.String("Name"),
+					Value: aws.String("test windows share"),
+				},
+			},
+		},
+	},
+	NextToken: aws.String("fs-123456789abcdef01"),
+}
+
+var testFSxDescribeFileSystemsOutputPage2 = &fsx.DescribeFileSystemsOutput{
+	FileSystems: []*fsx.FileSystem{
+		{
+			FileSystemId:        aws.String("fs-123456789abcdef01"),
+			FileSystemType:      aws.String(fsx.FileSystemTypeLustre),
+			DNSName:             aws.String("fs-123456789abcdef01.fsx.us-east-1.amazonaws.com"),
+			NetworkInterfaceIds: aws.StringSlice([]string{"eni-fsx33333"}),
+			ResourceARN:         aws.String("arn:aws:fsx:us-east-1:123456789012:file-system/fs-123456789abcdef01"),
+			StorageCapacity:     aws.Int64(6144),
+			StorageType:         aws.String(fsx.StorageTypeHdd),
+			SubnetIds:           aws.StringSlice([]string{"subnet-34567890"}),
+			VpcId:               aws.String("vpc-23456789"),
+		},
+	},
+}
+
+var testFSxNetworkInterfaces = map[string]*ec2.NetworkInterface{
+	"eni-fsx11111": testFSxNetworkInterface("eni-fsx11111", "10.0.1.60", "02:00:00:00:00:01", "subnet-12345678", "vpc-12345678"),
+	"eni-fsx22222": testFSxNetworkInterface("eni-fsx22222", "10.0.2.60", "02:00:00:00:00:02", "subnet-23456789", "vpc-12345678"),
+	"eni-fsx33333": testFSxNetworkInterface("eni-fsx33333", "10.0.3.70", "02:00:00:00:00:03", "subnet-34567890", "vpc-23456789"),
+}
+
+func testFSxNetworkInterface(id, ip, mac, subnet, vpc string) *ec2.NetworkInterface {
+	return &ec2.NetworkInterface{
+		NetworkInterfaceId: aws.String(id),
+		MacAddress:         aws.String(mac),
+		OwnerId:            aws.String("123456789012"),
+		SubnetId:           aws.String(subnet),
+		VpcId:              aws.String(vpc),
+		PrivateIpAddresses: []*ec2.NetworkInterfacePrivateIpAddress{
+			{
+				PrivateIpAddress: aws.String(ip),
+			},
+		},
+	}
+}
+
+// Mocks
+type FSxMock struct {
+	fsxiface.FSxAPI
+}
+
+func (f FSxMock) DescribeFileSystemsWithContext(ctx aws.Context, cfg *fsx.DescribeFileSystemsInput, opts ...request.Option) (*fsx.DescribeFileSystemsOutput, error) {
+	if cfg.NextToken == nil {
+		return testFSxDescribeFileSystemsOutputPage1, nil
+	}
+
+	return testFSxDescribeFileSystemsOutputPage2, nil
+}
+
+type FSxErrorMock struct {
+	fsxiface.FSxAPI
+}
+
+func (f FSxErrorMock) DescribeFileSystemsWithContext(ctx aws.Context, cfg *fsx.DescribeFileSystemsInput, opts ...request.Option) (*fsx.DescribeFileSystemsOutput, error) {
+	return &fsx.DescribeFileSystemsOutput{}, testError
+}
+
+type FSxEC2Mock struct {
+	ec2iface.EC2API
+}
+
+func (e FSxEC2Mock) DescribeNetworkInterfacesWithContext(ctx aws.Context, cfg *ec2.DescribeNetworkInterfacesInput, opts ...request.Option) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	out := &ec2.DescribeNetworkInterfacesOutput{}
+	for _, id := range cfg.NetworkInterfaceIds {
+		out.NetworkInterfaces = append(out.NetworkInterfaces, testFSxNetworkInterfaces[aws.StringValue(id)])
+	}
+
+	return out, nil
+}
+
+// Tests
+func TestCanLoadFSxFileSystems(t *testing.T) {
+	d := New(logrus.New(), TestClients{EC2: FSxEC2Mock{}, FSx: FSxMock{}})
+
+	var rows []inventory.Row
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceFSx}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	require.Equal(t, testFSxRows, rows)
+}
+
+func TestLoadFSxFileSystemsLogsError(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: FSxEC2Mock{}, FSx: FSxErrorMock{}})
+
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceFSx}, nil)
+
+	assertTestErrorWasLogged(t, hook.Entries)
+}
+
+func TestLoadFSxFileSystemsKeepsFileSystemsWhenNetworkInterfacesFail(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EC2ErrorMock{}, FSx: FSxMock{}})
+
+	var rows []inventory.Row
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceFSx}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	require.Len(t, rows, 2)
+	require.True(t, report.Complete())
+	for _, row := range rows {
+		require.Empty(t, row.IPv4orIPv6Address)
+	}
+}
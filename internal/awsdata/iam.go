@@ -1,8 +1,12 @@
 package awsdata
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/manywho/awsinventory/internal/inventory"
 	"github.com/sirupsen/logrus"
 )
@@ -15,13 +19,17 @@ const (
 	ServiceIAM string = "iam"
 )
 
-func (d *AWSData) loadIAMUsers() {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewCollector(ServiceIAM, true, func(ctx context.Context, d *AWSData, region string) {
+		d.loadIAMUsers(ctx, region)
+	}))
+}
 
-	iamSvc := d.clients.GetIAMClient(DefaultRegion)
+func (d *AWSData) loadIAMUsers(ctx context.Context, region string) {
+	iamSvc := d.clients.GetIAMClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
-		"region":  "global",
+		"region":  RegionGlobal,
 		"service": ServiceIAM,
 	})
 
@@ -31,10 +39,13 @@ func (d *AWSData) loadIAMUsers() {
 	done := false
 	params := &iam.ListUsersInput{}
 	for !done {
-		out, err := iamSvc.ListUsers(params)
-
+		var out *iam.ListUsersOutput
+		err := d.retry(ctx, ServiceIAM, func() (err error) {
+			out, err = iamSvc.ListUsersWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to list users: %s", err)
+			d.AddError(ServiceIAM, RegionGlobal, fmt.Errorf("failed to list users: %w", err))
 			return
 		}
 
@@ -50,13 +61,53 @@ func (d *AWSData) loadIAMUsers() {
 	log.Info("processing data")
 
 	for _, u := range users {
-		d.rows <- inventory.Row{
+		tags, err := d.listIAMUserTags(ctx, iamSvc, u.UserName)
+		if err != nil {
+			d.AddError(ServiceIAM, RegionGlobal, fmt.Errorf("failed to list tags for %s: %w", aws.StringValue(u.UserName), err))
+		}
+
+		d.AddRow(ServiceIAM, RegionGlobal, inventory.Row{
 			UniqueAssetIdentifier: aws.StringValue(u.UserName),
 			Virtual:               true,
 			AssetType:             AssetTypeIAMUser,
 			SerialAssetTagNumber:  aws.StringValue(u.Arn),
-		}
+			Tags:                  tags,
+		})
 	}
 
 	log.Info("finished processing data")
 }
+
+// listIAMUserTags returns the tags of the IAM user as a map, or nil when it has none
+func (d *AWSData) listIAMUserTags(ctx context.Context, iamSvc iamiface.IAMAPI, userName *string) (map[string]string, error) {
+	var tags map[string]string
+	done := false
+	params := &iam.ListUserTagsInput{
+		UserName: userName,
+	}
+	for !done {
+		var out *iam.ListUserTagsOutput
+		err := d.retry(ctx, ServiceIAM, func() (err error) {
+			out, err = iamSvc.ListUserTagsWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range out.Tags {
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+
+		if aws.BoolValue(out.IsTruncated) {
+			params.Marker = out.Marker
+		} else {
+			done = true
+		}
+	}
+
+	return tags, nil
+}
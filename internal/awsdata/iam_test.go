@@ -1,9 +1,11 @@
 package awsdata_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/sirupsen/logrus"
@@ -20,6 +22,9 @@ var testIAMRows = []inventory.Row{
 		Virtual:               true,
 		AssetType:             AssetTypeIAMUser,
 		SerialAssetTagNumber:  "arn:aws:iam::123456789012:user/test-user-1",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier: "test-user-2",
@@ -65,7 +70,7 @@ type IAMMock struct {
 	iamiface.IAMAPI
 }
 
-func (e IAMMock) ListUsers(cfg *iam.ListUsersInput) (*iam.ListUsersOutput, error) {
+func (e IAMMock) ListUsersWithContext(ctx aws.Context, cfg *iam.ListUsersInput, opts ...request.Option) (*iam.ListUsersOutput, error) {
 	if cfg.Marker == nil {
 		return testIAMListUsersOutputPage1, nil
 	}
@@ -73,11 +78,26 @@ func (e IAMMock) ListUsers(cfg *iam.ListUsersInput) (*iam.ListUsersOutput, error
 	return testIAMListUsersOutputPage2, nil
 }
 
+func (e IAMMock) ListUserTagsWithContext(ctx aws.Context, cfg *iam.ListUserTagsInput, opts ...request.Option) (*iam.ListUserTagsOutput, error) {
+	if aws.StringValue(cfg.UserName) != testIAMRows[0].UniqueAssetIdentifier {
+		return &iam.ListUserTagsOutput{}, nil
+	}
+
+	return &iam.ListUserTagsOutput{
+		Tags: []*iam.Tag{
+			{
+				Key:   aws.String("Owner"),
+				Value: aws.String("test owner"),
+			},
+		},
+	}, nil
+}
+
 type IAMErrorMock struct {
 	iamiface.IAMAPI
 }
 
-func (e IAMErrorMock) ListUsers(cfg *iam.ListUsersInput) (*iam.ListUsersOutput, error) {
+func (e IAMErrorMock) ListUsersWithContext(ctx aws.Context, cfg *iam.ListUsersInput, opts ...request.Option) (*iam.ListUsersOutput, error) {
 	return &iam.ListUsersOutput{}, testError
 }
 
@@ -86,7 +106,7 @@ func TestCanLoadIAMUsers(t *testing.T) {
 	d := New(logrus.New(), TestClients{IAM: IAMMock{}})
 
 	var count int
-	d.Load([]string{}, []string{ServiceIAM}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{}, []string{ServiceIAM}, func(row inventory.Row) error {
 		require.Equal(t, testIAMRows[count], row)
 		count++
 		return nil
@@ -99,7 +119,7 @@ func TestLoadIAMUsersLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{IAM: IAMErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceIAM}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceIAM}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
@@ -0,0 +1,105 @@
+//go:build integration
+// +build integration
+
+package awsdata_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+// The integration tests run against a stand-in for AWS such as LocalStack, at AWSINVENTORY_ENDPOINT_URL
+const testIntegrationEndpointURL = "http://localhost:4566"
+
+const testIntegrationName = "awsinventory-integration"
+
+func newIntegrationClients(t *testing.T) *DefaultClients {
+	endpoint := os.Getenv("AWSINVENTORY_ENDPOINT_URL")
+	if endpoint == "" {
+		endpoint = testIntegrationEndpointURL
+	}
+
+	// Stand-ins accept any credentials
+	for _, env := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"} {
+		if os.Getenv(env) == "" {
+			env := env
+			os.Setenv(env, "test")
+			t.Cleanup(func() {
+				os.Unsetenv(env)
+			})
+		}
+	}
+
+	c, err := NewDefaultClients(SessionOptions{EndpointURL: endpoint})
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestIntegrationLoad(t *testing.T) {
+	ctx := context.Background()
+	c := newIntegrationClients(t)
+
+	_, err := c.GetDynamoDBClient(DefaultRegion).CreateTableWithContext(ctx, &dynamodb.CreateTableInput{
+		TableName:   aws.String(testIntegrationName),
+		BillingMode: aws.String(dynamodb.BillingModePayPerRequest),
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String("id"),
+				AttributeType: aws.String(dynamodb.ScalarAttributeTypeS),
+			},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String("id"),
+				KeyType:       aws.String(dynamodb.KeyTypeHash),
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer c.GetDynamoDBClient(DefaultRegion).DeleteTable(&dynamodb.DeleteTableInput{
+		TableName: aws.String(testIntegrationName),
+	})
+
+	_, err = c.GetS3Client(DefaultRegion).CreateBucketWithContext(ctx, &s3.CreateBucketInput{
+		Bucket: aws.String(testIntegrationName),
+	})
+	require.NoError(t, err)
+	defer c.GetS3Client(DefaultRegion).DeleteBucket(&s3.DeleteBucketInput{
+		Bucket: aws.String(testIntegrationName),
+	})
+
+	queue, err := c.GetSQSClient(DefaultRegion).CreateQueueWithContext(ctx, &sqs.CreateQueueInput{
+		QueueName: aws.String(testIntegrationName),
+	})
+	require.NoError(t, err)
+	defer c.GetSQSClient(DefaultRegion).DeleteQueue(&sqs.DeleteQueueInput{
+		QueueUrl: queue.QueueUrl,
+	})
+
+	d := New(logrus.New(), c)
+
+	found := make(map[string]bool)
+	report := d.Load(ctx, []string{DefaultRegion}, []string{ServiceDynamoDB, ServiceS3, ServiceSQS}, func(row inventory.Row) error {
+		if row.UniqueAssetIdentifier == testIntegrationName {
+			found[row.AssetType] = true
+		}
+		return nil
+	})
+
+	require.True(t, report.Complete(), "expected no errors, got %v", report.Errors)
+	require.True(t, found[AssetTypeDynamoDBTable], "failed to find table")
+	require.True(t, found[AssetTypeS3Bucket], "failed to find bucket")
+	require.True(t, found[AssetTypeSQSQueue], "failed to find queue")
+}
@@ -1,6 +1,7 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -20,9 +21,13 @@ const (
 	ServiceKMS string = "kms"
 )
 
-func (d *AWSData) loadKMSKeys(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceKMS, kms.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadKMSKeys(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadKMSKeys(ctx context.Context, region string) {
 	kmsSvc := d.clients.GetKMSClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -36,10 +41,13 @@ func (d *AWSData) loadKMSKeys(region string) {
 	done := false
 	params := &kms.ListKeysInput{}
 	for !done {
-		out, err := kmsSvc.ListKeys(params)
-
+		var out *kms.ListKeysOutput
+		err := d.retry(ctx, ServiceKMS, func() (err error) {
+			out, err = kmsSvc.ListKeysWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to list keys: %s", err)
+			d.AddError(ServiceKMS, region, fmt.Errorf("failed to list keys: %w", err))
 			return
 		}
 
@@ -56,20 +64,24 @@ func (d *AWSData) loadKMSKeys(region string) {
 
 	for _, k := range keys {
 		d.wg.Add(1)
-		go d.processKMSKey(log, kmsSvc, k, region)
+		go d.processKMSKey(ctx, log, kmsSvc, k, region)
 	}
 
 	log.Info("finished processing data")
 }
 
-func (d *AWSData) processKMSKey(log *logrus.Entry, kmsSvc kmsiface.KMSAPI, key *kms.KeyListEntry, region string) {
+func (d *AWSData) processKMSKey(ctx context.Context, log *logrus.Entry, kmsSvc kmsiface.KMSAPI, key *kms.KeyListEntry, region string) {
 	defer d.wg.Done()
 
-	out, err := kmsSvc.DescribeKey(&kms.DescribeKeyInput{
-		KeyId: key.KeyId,
+	var out *kms.DescribeKeyOutput
+	err := d.retry(ctx, ServiceKMS, func() (err error) {
+		out, err = kmsSvc.DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{
+			KeyId: key.KeyId,
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to describe key %s: %s", aws.StringValue(key.KeyId), err)
+		d.AddError(ServiceKMS, region, fmt.Errorf("failed to describe key %s: %w", aws.StringValue(key.KeyId), err))
 		return
 	}
 
@@ -80,7 +92,21 @@ func (d *AWSData) processKMSKey(log *logrus.Entry, kmsSvc kmsiface.KMSAPI, key *
 		comments = append(comments, "Valid to: "+aws.TimeValue(out.KeyMetadata.ValidTo).Format(time.RFC3339))
 	}
 
-	d.rows <- inventory.Row{
+	var tags map[string]string
+	var outTags *kms.ListResourceTagsOutput
+	err = d.retry(ctx, ServiceKMS, func() (err error) {
+		outTags, err = kmsSvc.ListResourceTagsWithContext(ctx, &kms.ListResourceTagsInput{
+			KeyId: key.KeyId,
+		})
+		return
+	})
+	if err != nil {
+		d.AddError(ServiceKMS, region, fmt.Errorf("failed to list tags for %s: %w", aws.StringValue(key.KeyId), err))
+	} else {
+		tags = tagMap(len(outTags.Tags), func(i int) (*string, *string) { return outTags.Tags[i].TagKey, outTags.Tags[i].TagValue })
+	}
+
+	d.AddRow(ServiceKMS, region, inventory.Row{
 		UniqueAssetIdentifier:     aws.StringValue(out.KeyMetadata.KeyId),
 		Virtual:                   true,
 		Public:                    false,
@@ -90,5 +116,6 @@ func (d *AWSData) processKMSKey(log *logrus.Entry, kmsSvc kmsiface.KMSAPI, key *
 		Comments:                  strings.Join(comments, "\n"),
 		SerialAssetTagNumber:      aws.StringValue(out.KeyMetadata.Arn),
 		Function:                  aws.StringValue(out.KeyMetadata.Description),
-	}
+		Tags:                      tags,
+	})
 }
@@ -1,11 +1,13 @@
 package awsdata_test
 
 import (
+	"context"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/sirupsen/logrus"
@@ -27,6 +29,9 @@ var testKMSKeyRows = []inventory.Row{
 		Comments:                  "AWS, SYMMETRIC_DEFAULT\nCreated at: 2019-10-10T20:00:00Z",
 		SerialAssetTagNumber:      "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
 		Function:                  "Test key 1",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier:     "b735f38f-81b2-462b-899a-a281ae69b844",
@@ -79,7 +84,7 @@ type KMSMock struct {
 	kmsiface.KMSAPI
 }
 
-func (e KMSMock) ListKeys(cfg *kms.ListKeysInput) (*kms.ListKeysOutput, error) {
+func (e KMSMock) ListKeysWithContext(ctx aws.Context, cfg *kms.ListKeysInput, opts ...request.Option) (*kms.ListKeysOutput, error) {
 	if cfg.Marker == nil {
 		return testKMSListKeysOutputPage1, nil
 	}
@@ -87,7 +92,7 @@ func (e KMSMock) ListKeys(cfg *kms.ListKeysInput) (*kms.ListKeysOutput, error) {
 	return testKMSListKeysOutputPage2, nil
 }
 
-func (e KMSMock) DescribeKey(cfg *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+func (e KMSMock) DescribeKeyWithContext(ctx aws.Context, cfg *kms.DescribeKeyInput, opts ...request.Option) (*kms.DescribeKeyOutput, error) {
 	var customerMasterKeySpec, keyManager string
 	var creationDate, validTo time.Time
 	var row int
@@ -123,15 +128,30 @@ func (e KMSMock) DescribeKey(cfg *kms.DescribeKeyInput) (*kms.DescribeKeyOutput,
 	}, nil
 }
 
+func (e KMSMock) ListResourceTagsWithContext(ctx aws.Context, cfg *kms.ListResourceTagsInput, opts ...request.Option) (*kms.ListResourceTagsOutput, error) {
+	if aws.StringValue(cfg.KeyId) != testKMSKeyRows[0].UniqueAssetIdentifier {
+		return &kms.ListResourceTagsOutput{}, nil
+	}
+
+	return &kms.ListResourceTagsOutput{
+		Tags: []*kms.Tag{
+			{
+				TagKey:   aws.String("Owner"),
+				TagValue: aws.String("test owner"),
+			},
+		},
+	}, nil
+}
+
 type KMSErrorMock struct {
 	kmsiface.KMSAPI
 }
 
-func (e KMSErrorMock) ListKeys(cfg *kms.ListKeysInput) (*kms.ListKeysOutput, error) {
+func (e KMSErrorMock) ListKeysWithContext(ctx aws.Context, cfg *kms.ListKeysInput, opts ...request.Option) (*kms.ListKeysOutput, error) {
 	return &kms.ListKeysOutput{}, testError
 }
 
-func (e KMSErrorMock) DescribeKey(cfg *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+func (e KMSErrorMock) DescribeKeyWithContext(ctx aws.Context, cfg *kms.DescribeKeyInput, opts ...request.Option) (*kms.DescribeKeyOutput, error) {
 	return &kms.DescribeKeyOutput{}, testError
 }
 
@@ -140,7 +160,7 @@ func TestCanLoadKMSKeys(t *testing.T) {
 	d := New(logrus.New(), TestClients{KMS: KMSMock{}})
 
 	var rows []inventory.Row
-	d.Load([]string{DefaultRegion}, []string{ServiceKMS}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceKMS}, func(row inventory.Row) error {
 		rows = append(rows, row)
 		return nil
 	})
@@ -161,7 +181,7 @@ func TestLoadKMSKeysLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{KMS: KMSErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceKMS}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceKMS}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
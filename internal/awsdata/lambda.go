@@ -1,6 +1,7 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -17,9 +18,13 @@ const (
 	ServiceLambda string = "lambda"
 )
 
-func (d *AWSData) loadLambdaFunctions(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceLambda, lambda.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadLambdaFunctions(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadLambdaFunctions(ctx context.Context, region string) {
 	lambdaSvc := d.clients.GetLambdaClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -33,9 +38,13 @@ func (d *AWSData) loadLambdaFunctions(region string) {
 	done := false
 	params := &lambda.ListFunctionsInput{}
 	for !done {
-		out, err := lambdaSvc.ListFunctions(params)
+		var out *lambda.ListFunctionsOutput
+		err := d.retry(ctx, ServiceLambda, func() (err error) {
+			out, err = lambdaSvc.ListFunctionsWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to list functions: %s", err)
+			d.AddError(ServiceLambda, region, fmt.Errorf("failed to list functions: %w", err))
 			return
 		}
 
@@ -56,7 +65,21 @@ func (d *AWSData) loadLambdaFunctions(region string) {
 			vpcID = aws.StringValue(f.VpcConfig.VpcId)
 		}
 
-		d.rows <- inventory.Row{
+		var tags map[string]string
+		var outTags *lambda.ListTagsOutput
+		err := d.retry(ctx, ServiceLambda, func() (err error) {
+			outTags, err = lambdaSvc.ListTagsWithContext(ctx, &lambda.ListTagsInput{
+				Resource: f.FunctionArn,
+			})
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceLambda, region, fmt.Errorf("failed to list tags for %s: %w", aws.StringValue(f.FunctionName), err))
+		} else {
+			tags = stringMapTags(outTags.Tags)
+		}
+
+		d.AddRow(ServiceLambda, region, inventory.Row{
 			UniqueAssetIdentifier:          aws.StringValue(f.FunctionName),
 			Virtual:                        true,
 			BaselineConfigurationName:      aws.StringValue(f.Version),
@@ -68,7 +91,8 @@ func (d *AWSData) loadLambdaFunctions(region string) {
 			Comments:                       fmt.Sprintf("%ds, %dMB", aws.Int64Value(f.Timeout), aws.Int64Value(f.MemorySize)),
 			SerialAssetTagNumber:           aws.StringValue(f.FunctionArn),
 			VLANNetworkID:                  vpcID,
-		}
+			Tags:                           tags,
+		})
 	}
 
 	log.Info("finished processing data")
@@ -1,9 +1,11 @@
 package awsdata_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/sirupsen/logrus"
@@ -27,6 +29,9 @@ var testLambdaFunctionRows = []inventory.Row{
 		Comments:                       "10s, 128MB",
 		SerialAssetTagNumber:           "arn:aws:lambda:us-east-1:012345678910:function:function-1",
 		VLANNetworkID:                  "vpc-12345678",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier:          "function-2",
@@ -109,7 +114,7 @@ type LambdaMock struct {
 	lambdaiface.LambdaAPI
 }
 
-func (e LambdaMock) ListFunctions(cfg *lambda.ListFunctionsInput) (*lambda.ListFunctionsOutput, error) {
+func (e LambdaMock) ListFunctionsWithContext(ctx aws.Context, cfg *lambda.ListFunctionsInput, opts ...request.Option) (*lambda.ListFunctionsOutput, error) {
 	if cfg.Marker == nil {
 		return testLambdaListFunctionsOutputPage1, nil
 	}
@@ -117,11 +122,23 @@ func (e LambdaMock) ListFunctions(cfg *lambda.ListFunctionsInput) (*lambda.ListF
 	return testLambdaListFunctionsOutputPage2, nil
 }
 
+func (e LambdaMock) ListTagsWithContext(ctx aws.Context, cfg *lambda.ListTagsInput, opts ...request.Option) (*lambda.ListTagsOutput, error) {
+	if aws.StringValue(cfg.Resource) != testLambdaFunctionRows[0].SerialAssetTagNumber {
+		return &lambda.ListTagsOutput{}, nil
+	}
+
+	return &lambda.ListTagsOutput{
+		Tags: map[string]*string{
+			"Owner": aws.String("test owner"),
+		},
+	}, nil
+}
+
 type LambdaErrorMock struct {
 	lambdaiface.LambdaAPI
 }
 
-func (e LambdaErrorMock) ListFunctions(cfg *lambda.ListFunctionsInput) (*lambda.ListFunctionsOutput, error) {
+func (e LambdaErrorMock) ListFunctionsWithContext(ctx aws.Context, cfg *lambda.ListFunctionsInput, opts ...request.Option) (*lambda.ListFunctionsOutput, error) {
 	return &lambda.ListFunctionsOutput{}, testError
 }
 
@@ -130,7 +147,7 @@ func TestCanLoadLambdaFunctions(t *testing.T) {
 	d := New(logrus.New(), TestClients{Lambda: LambdaMock{}})
 
 	var count int
-	d.Load([]string{DefaultRegion}, []string{ServiceLambda}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceLambda}, func(row inventory.Row) error {
 		require.Equal(t, testLambdaFunctionRows[count], row)
 		count++
 		return nil
@@ -143,7 +160,7 @@ func TestLoadLambdaFunctionsLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{Lambda: LambdaErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceLambda}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceLambda}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
@@ -0,0 +1,97 @@
+package awsdata
+
+import "time"
+
+// Option configures an AWSData returned by New
+type Option func(*AWSData)
+
+// WithMaxConcurrency limits how many AWS API requests are in flight at once across all services.
+// A limit of zero or less means no limit, which is the default.
+func WithMaxConcurrency(n int) Option {
+	return func(d *AWSData) {
+		d.maxConcurrency = n
+	}
+}
+
+// WithServiceConcurrency limits how many AWS API requests are in flight at once for a single service,
+// in addition to any overall limit. Requests to the EC2 API count against ServiceEC2, whichever service they
+// load data for. A limit of zero or less means no limit, which is the default.
+func WithServiceConcurrency(service string, n int) Option {
+	return func(d *AWSData) {
+		d.serviceConcurrency[service] = n
+	}
+}
+
+// WithAccount sets the account the clients belong to, which is added to every row loaded
+func WithAccount(account Account) Option {
+	return func(d *AWSData) {
+		d.account = &account
+	}
+}
+
+// WithAllRegions makes Load gather data from every region enabled for the account, as returned by EnabledRegions,
+// instead of the regions it is given
+func WithAllRegions() Option {
+	return func(d *AWSData) {
+		d.allRegions = true
+	}
+}
+
+// WithTagMapping sets fields of every row from the tags of its resource, when the resource has them.
+// The mapping is keyed by field name, as used in the json format, with the tag key as the value,
+// e.g. system_administrator_owner: Owner.
+func WithTagMapping(mapping map[string]string) Option {
+	return func(d *AWSData) {
+		for field, key := range mapping {
+			d.tagMapping[field] = key
+		}
+	}
+}
+
+// WithIncludeTag only keeps rows for resources with the tag, whose value matches the glob pattern, e.g. *.
+// Resources must have every tag included. Rows for services whose tags are not loaded are left out.
+func WithIncludeTag(key, pattern string) Option {
+	return func(d *AWSData) {
+		d.includeTags[key] = pattern
+	}
+}
+
+// WithExcludeTag leaves out rows for resources with the tag, whose value matches the glob pattern
+func WithExcludeTag(key, pattern string) Option {
+	return func(d *AWSData) {
+		d.excludeTags[key] = pattern
+	}
+}
+
+// WithIncludeResource only keeps rows whose unique asset identifier or serial number, usually an ARN, matches the
+// glob pattern, e.g. arn:aws:s3:::prod-*. Unlike path.Match, * also matches /, so arn:aws:ecs:* matches every
+// ECS ARN. When included more than once, rows matching any of the patterns are kept.
+func WithIncludeResource(pattern string) Option {
+	return func(d *AWSData) {
+		d.includeResources = append(d.includeResources, pattern)
+	}
+}
+
+// WithExcludeResource leaves out rows whose unique asset identifier or serial number, usually an ARN, matches the
+// glob pattern
+func WithExcludeResource(pattern string) Option {
+	return func(d *AWSData) {
+		d.excludeResources = append(d.excludeResources, pattern)
+	}
+}
+
+// WithMaxRetries sets how many times a throttled page of results is retried before the service is given up on.
+// The default is DefaultMaxRetries.
+func WithMaxRetries(n int) Option {
+	return func(d *AWSData) {
+		d.maxRetries = n
+	}
+}
+
+// WithRetryDelay sets the base delay used for the exponential backoff between retries of throttled requests.
+// The default is DefaultRetryDelay.
+func WithRetryDelay(delay time.Duration) Option {
+	return func(d *AWSData) {
+		d.retryDelay = delay
+	}
+}
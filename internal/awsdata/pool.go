@@ -0,0 +1,63 @@
+package awsdata
+
+import "context"
+
+// pool limits how many AWS API requests are in flight at once, overall and for each service.
+// Room is only held for the duration of a single request, see retry. A limit of zero or less means no limit.
+type pool struct {
+	all      chan struct{}
+	services map[string]chan struct{}
+}
+
+func newPool(max int, perService map[string]int) *pool {
+	p := &pool{
+		services: make(map[string]chan struct{}),
+	}
+
+	if max > 0 {
+		p.all = make(chan struct{}, max)
+	}
+
+	for service, n := range perService {
+		if n > 0 {
+			p.services[service] = make(chan struct{}, n)
+		}
+	}
+
+	return p
+}
+
+// acquire blocks until there is room for another request for the service, or the context is done
+func (p *pool) acquire(ctx context.Context, service string) error {
+	if sem, ok := p.services[service]; ok {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if p.all != nil {
+		select {
+		case p.all <- struct{}{}:
+		case <-ctx.Done():
+			if sem, ok := p.services[service]; ok {
+				<-sem
+			}
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// release frees the room taken by a previous call to acquire for the service
+func (p *pool) release(service string) {
+	if p.all != nil {
+		<-p.all
+	}
+
+	if sem, ok := p.services[service]; ok {
+		<-sem
+	}
+}
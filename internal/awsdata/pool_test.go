@@ -0,0 +1,139 @@
+package awsdata_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+)
+
+const testConcurrencyBuckets = 20
+
+// Mocks
+
+// concurrency records the most requests made to a mock at once
+type concurrency struct {
+	lock    sync.Mutex
+	current int
+	max     int
+}
+
+// request records the number of requests in flight while it waits for a while
+func (c *concurrency) request() {
+	c.lock.Lock()
+	c.current++
+	if c.current > c.max {
+		c.max = c.current
+	}
+	c.lock.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c.lock.Lock()
+	c.current--
+	c.lock.Unlock()
+}
+
+type S3ConcurrencyMock struct {
+	s3iface.S3API
+	*concurrency
+}
+
+func newS3ConcurrencyMock() S3ConcurrencyMock {
+	return S3ConcurrencyMock{
+		concurrency: &concurrency{},
+	}
+}
+
+type EC2ConcurrencyMock struct {
+	EIPMock
+	*concurrency
+}
+
+func (e EC2ConcurrencyMock) DescribeNetworkInterfacesWithContext(ctx aws.Context, cfg *ec2.DescribeNetworkInterfacesInput, opts ...request.Option) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	e.request()
+	return e.EIPMock.DescribeNetworkInterfacesWithContext(ctx, cfg, opts...)
+}
+
+func (e EC2ConcurrencyMock) DescribeAddressesWithContext(ctx aws.Context, cfg *ec2.DescribeAddressesInput, opts ...request.Option) (*ec2.DescribeAddressesOutput, error) {
+	e.request()
+	return e.EIPMock.DescribeAddressesWithContext(ctx, cfg, opts...)
+}
+
+func (e S3ConcurrencyMock) ListBucketsWithContext(ctx aws.Context, cfg *s3.ListBucketsInput, opts ...request.Option) (*s3.ListBucketsOutput, error) {
+	out := &s3.ListBucketsOutput{}
+	for i := 0; i < testConcurrencyBuckets; i++ {
+		out.Buckets = append(out.Buckets, &s3.Bucket{
+			Name: aws.String(fmt.Sprintf("test-bucket-%d", i)),
+		})
+	}
+
+	return out, nil
+}
+
+func (e S3ConcurrencyMock) GetBucketLocationWithContext(ctx aws.Context, cfg *s3.GetBucketLocationInput, opts ...request.Option) (*s3.GetBucketLocationOutput, error) {
+	e.request()
+	return testS3GetBucketLocationOutput, nil
+}
+
+func (e S3ConcurrencyMock) GetBucketTaggingWithContext(ctx aws.Context, cfg *s3.GetBucketTaggingInput, opts ...request.Option) (*s3.GetBucketTaggingOutput, error) {
+	e.request()
+	return &s3.GetBucketTaggingOutput{}, nil
+}
+
+// Tests
+func TestLoadHonoursMaxConcurrency(t *testing.T) {
+	mock := newS3ConcurrencyMock()
+
+	d := New(logrus.New(), TestClients{S3: mock}, WithMaxConcurrency(3))
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceS3}, nil)
+
+	require.True(t, report.Complete())
+	require.Equal(t, testConcurrencyBuckets, report.Count())
+	require.True(t, mock.max <= 3, "expected at most 3 concurrent calls, got %d", mock.max)
+}
+
+func TestLoadHonoursServiceConcurrency(t *testing.T) {
+	mock := newS3ConcurrencyMock()
+
+	d := New(logrus.New(), TestClients{S3: mock, DynamoDB: DynamoDBMock{}}, WithMaxConcurrency(10), WithServiceConcurrency(ServiceS3, 1))
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceS3, ServiceDynamoDB}, nil)
+
+	require.True(t, report.Complete())
+	require.Equal(t, testConcurrencyBuckets, report.Rows[ServiceS3][DefaultRegion])
+	require.Equal(t, 3, report.Rows[ServiceDynamoDB][DefaultRegion])
+	require.Equal(t, 1, mock.max)
+}
+
+func TestLoadLimitsEC2RequestsOfEveryService(t *testing.T) {
+	mock := EC2ConcurrencyMock{concurrency: &concurrency{}}
+
+	d := New(logrus.New(), TestClients{EC2: mock}, WithServiceConcurrency(ServiceEC2, 1))
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceENI, ServiceEIP}, nil)
+
+	require.True(t, report.Complete())
+	require.Equal(t, 1, mock.max)
+}
+
+func TestLoadCatchesInvalidServiceConcurrency(t *testing.T) {
+	d := New(logrus.New(), TestClients{}, WithServiceConcurrency("invalid-service", 1))
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceS3}, nil)
+
+	require.False(t, report.Complete())
+	require.EqualError(t, report.Errors[0], "invalid service: invalid-service")
+}
@@ -1,10 +1,13 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/manywho/awsinventory/internal/inventory"
 	"github.com/sirupsen/logrus"
 )
@@ -13,13 +16,23 @@ const (
 	// AssetTypeRDSInstance is the value used in the AssetType field when fetching RDS instances
 	AssetTypeRDSInstance string = "RDS Instance"
 
+	// AssetTypeRDSCluster is the value used in the AssetType field when fetching RDS and Aurora DB clusters
+	AssetTypeRDSCluster string = "RDS Cluster"
+
 	// ServiceRDS is the key for the RDS service
 	ServiceRDS string = "rds"
 )
 
-func (d *AWSData) loadRDSInstances(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceRDS, rds.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		instances := d.loadRDSInstances(ctx, region)
+		d.loadRDSClusters(ctx, region, instances)
+	}))
+}
 
+// loadRDSInstances adds a row for each DB instance, returning the instances keyed by their identifier, or nil when
+// they fail to load
+func (d *AWSData) loadRDSInstances(ctx context.Context, region string) map[string]*rds.DBInstance {
 	rdsSvc := d.clients.GetRDSClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -33,11 +46,14 @@ func (d *AWSData) loadRDSInstances(region string) {
 	done := false
 	params := &rds.DescribeDBInstancesInput{}
 	for !done {
-		out, err := rdsSvc.DescribeDBInstances(params)
-
-		if err != nil {
-			log.Errorf("failed to describe db instances: %s", err)
+		var out *rds.DescribeDBInstancesOutput
+		err := d.retry(ctx, ServiceRDS, func() (err error) {
+			out, err = rdsSvc.DescribeDBInstancesWithContext(ctx, params)
 			return
+		})
+		if err != nil {
+			d.AddError(ServiceRDS, region, fmt.Errorf("failed to describe db instances: %w", err))
+			return nil
 		}
 
 		dbInstances = append(dbInstances, out.DBInstances...)
@@ -51,8 +67,16 @@ func (d *AWSData) loadRDSInstances(region string) {
 
 	log.Info("processing data")
 
+	instances := make(map[string]*rds.DBInstance)
 	for _, i := range dbInstances {
-		d.rows <- inventory.Row{
+		instances[aws.StringValue(i.DBInstanceIdentifier)] = i
+
+		var comments string
+		if i.DBClusterIdentifier != nil {
+			comments = "Cluster: " + aws.StringValue(i.DBClusterIdentifier)
+		}
+
+		d.AddRow(ServiceRDS, region, inventory.Row{
 			UniqueAssetIdentifier:          aws.StringValue(i.DBInstanceIdentifier),
 			Virtual:                        true,
 			Public:                         aws.BoolValue(i.PubliclyAccessible),
@@ -62,10 +86,132 @@ func (d *AWSData) loadRDSInstances(region string) {
 			HardwareMakeModel:              aws.StringValue(i.DBInstanceClass),
 			SoftwareDatabaseVendor:         aws.StringValue(i.Engine),
 			SoftwareDatabaseNameAndVersion: fmt.Sprintf("%s %s", aws.StringValue(i.Engine), aws.StringValue(i.EngineVersion)),
+			Comments:                       comments,
 			SerialAssetTagNumber:           aws.StringValue(i.DBInstanceArn),
 			VLANNetworkID:                  aws.StringValue(i.DBSubnetGroup.VpcId),
-		}
+			Tags:                           tagMap(len(i.TagList), func(j int) (*string, *string) { return i.TagList[j].Key, i.TagList[j].Value }),
+		})
 	}
 
 	log.Info("finished processing data")
+
+	return instances
+}
+
+// loadRDSClusters adds a row for each DB cluster, such as an Aurora cluster, listing its endpoints and the identifiers
+// of its member instances. A cluster is public when any of the given member instances is.
+func (d *AWSData) loadRDSClusters(ctx context.Context, region string, instances map[string]*rds.DBInstance) {
+	rdsSvc := d.clients.GetRDSClient(region)
+
+	log := d.log.WithFields(logrus.Fields{
+		"region":  region,
+		"service": ServiceRDS,
+	})
+
+	log.Info("loading clusters")
+
+	var dbClusters []*rds.DBCluster
+	done := false
+	params := &rds.DescribeDBClustersInput{}
+	for !done {
+		var out *rds.DescribeDBClustersOutput
+		err := d.retry(ctx, ServiceRDS, func() (err error) {
+			out, err = rdsSvc.DescribeDBClustersWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceRDS, region, fmt.Errorf("failed to describe db clusters: %w", err))
+			return
+		}
+
+		dbClusters = append(dbClusters, out.DBClusters...)
+
+		if out.Marker == nil {
+			done = true
+		} else {
+			params.Marker = out.Marker
+		}
+	}
+
+	log.Info("processing clusters")
+
+	vpcIDs := make(map[string]string)
+	for _, c := range dbClusters {
+		subnetGroup := aws.StringValue(c.DBSubnetGroup)
+		if _, ok := vpcIDs[subnetGroup]; !ok && subnetGroup != "" {
+			vpcIDs[subnetGroup] = d.describeRDSSubnetGroupVPC(ctx, log, rdsSvc, c.DBSubnetGroup)
+		}
+
+		dnsNames := []string{aws.StringValue(c.Endpoint)}
+		if c.ReaderEndpoint != nil {
+			dnsNames = append(dnsNames, aws.StringValue(c.ReaderEndpoint))
+		}
+		dnsNames = append(dnsNames, aws.StringValueSlice(c.CustomEndpoints)...)
+
+		encrypted := "No"
+		if aws.BoolValue(c.StorageEncrypted) {
+			encrypted = "Yes"
+		}
+
+		var public bool
+		var members []string
+		for _, m := range c.DBClusterMembers {
+			role := "reader"
+			if aws.BoolValue(m.IsClusterWriter) {
+				role = "writer"
+			}
+			members = append(members, fmt.Sprintf("%s (%s)", aws.StringValue(m.DBInstanceIdentifier), role))
+
+			if i, ok := instances[aws.StringValue(m.DBInstanceIdentifier)]; ok && aws.BoolValue(i.PubliclyAccessible) {
+				public = true
+			}
+		}
+
+		comments := []string{
+			"Engine mode: " + aws.StringValue(c.EngineMode),
+			"Encrypted: " + encrypted,
+		}
+		if len(members) > 0 {
+			comments = append(comments, "Members: "+strings.Join(members, ", "))
+		}
+
+		d.AddRow(ServiceRDS, region, inventory.Row{
+			UniqueAssetIdentifier:          aws.StringValue(c.DBClusterIdentifier),
+			Virtual:                        true,
+			Public:                         public,
+			DNSNameOrURL:                   strings.Join(dnsNames, "\n"),
+			Location:                       region,
+			AssetType:                      AssetTypeRDSCluster,
+			SoftwareDatabaseVendor:         aws.StringValue(c.Engine),
+			SoftwareDatabaseNameAndVersion: fmt.Sprintf("%s %s", aws.StringValue(c.Engine), aws.StringValue(c.EngineVersion)),
+			Comments:                       strings.Join(comments, "\n"),
+			SerialAssetTagNumber:           aws.StringValue(c.DBClusterArn),
+			VLANNetworkID:                  vpcIDs[subnetGroup],
+			Tags:                           tagMap(len(c.TagList), func(i int) (*string, *string) { return c.TagList[i].Key, c.TagList[i].Value }),
+		})
+	}
+
+	log.Info("finished processing clusters")
+}
+
+// describeRDSSubnetGroupVPC returns the ID of the VPC of the named DB subnet group, or an empty string when it cannot
+// be described
+func (d *AWSData) describeRDSSubnetGroupVPC(ctx context.Context, log *logrus.Entry, rdsSvc rdsiface.RDSAPI, name *string) string {
+	var out *rds.DescribeDBSubnetGroupsOutput
+	err := d.retry(ctx, ServiceRDS, func() (err error) {
+		out, err = rdsSvc.DescribeDBSubnetGroupsWithContext(ctx, &rds.DescribeDBSubnetGroupsInput{
+			DBSubnetGroupName: name,
+		})
+		return
+	})
+	if err != nil {
+		log.Warningf("failed to describe db subnet group %s: %s", aws.StringValue(name), err)
+		return ""
+	}
+
+	if len(out.DBSubnetGroups) == 0 {
+		return ""
+	}
+
+	return aws.StringValue(out.DBSubnetGroups[0].VpcId)
 }
@@ -1,9 +1,11 @@
 package awsdata_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/sirupsen/logrus"
@@ -54,6 +56,50 @@ var testRDSInstanceRows = []inventory.Row{
 		SerialAssetTagNumber:           "arn:aws:rds:us-east-1:123456789012:db:test-db-3",
 		VLANNetworkID:                  "vpc-a1b2c3d4",
 	},
+	{
+		UniqueAssetIdentifier:          "test-db-4",
+		Virtual:                        true,
+		Public:                         true,
+		DNSNameOrURL:                   "test-db-4.rds.aws.amazon.com",
+		Location:                       DefaultRegion,
+		AssetType:                      "RDS Instance",
+		HardwareMakeModel:              "db.r5.large",
+		SoftwareDatabaseVendor:         "aurora-mysql",
+		SoftwareDatabaseNameAndVersion: "aurora-mysql 5.7.mysql_aurora.2.09.2",
+		Comments:                       "Cluster: test-cluster-1",
+		SerialAssetTagNumber:           "arn:aws:rds:us-east-1:123456789012:db:test-db-4",
+		VLANNetworkID:                  "vpc-12345678",
+	},
+}
+
+var testRDSClusterRows = []inventory.Row{
+	{
+		UniqueAssetIdentifier:          "test-cluster-1",
+		Virtual:                        true,
+		Public:                         true,
+		DNSNameOrURL:                   "test-cluster-1.cluster-abcdefghijkl.us-east-1.rds.amazonaws.com\ntest-cluster-1.cluster-ro-abcdefghijkl.us-east-1.rds.amazonaws.com\nanalytics.cluster-custom-abcdefghijkl.us-east-1.rds.amazonaws.com",
+		Location:                       DefaultRegion,
+		AssetType:                      "RDS Cluster",
+		SoftwareDatabaseVendor:         "aurora-mysql",
+		SoftwareDatabaseNameAndVersion: "aurora-mysql 5.7.mysql_aurora.2.09.2",
+		Comments:                       "Engine mode: provisioned\nEncrypted: No\nMembers: test-db-4 (writer), test-db-5 (reader)",
+		SerialAssetTagNumber:           "arn:aws:rds:us-east-1:123456789012:cluster:test-cluster-1",
+		VLANNetworkID:                  "vpc-12345678",
+		Tags:                           map[string]string{"Owner": "test owner"},
+	},
+	{
+		UniqueAssetIdentifier:          "test-cluster-2",
+		Virtual:                        true,
+		Public:                         false,
+		DNSNameOrURL:                   "test-cluster-2.cluster-abcdefghijkl.us-east-1.rds.amazonaws.com",
+		Location:                       DefaultRegion,
+		AssetType:                      "RDS Cluster",
+		SoftwareDatabaseVendor:         "aurora-postgresql",
+		SoftwareDatabaseNameAndVersion: "aurora-postgresql 10.14",
+		Comments:                       "Engine mode: serverless\nEncrypted: Yes",
+		SerialAssetTagNumber:           "arn:aws:rds:us-east-1:123456789012:cluster:test-cluster-2",
+		VLANNetworkID:                  "vpc-abcdefgh",
+	},
 }
 
 // Test Data
@@ -107,15 +153,86 @@ var testRDSDescribeDBInstancesOutputPage2 = &rds.DescribeDBInstancesOutput{
 				VpcId: aws.String(testRDSInstanceRows[2].VLANNetworkID),
 			},
 		},
+		{
+			DBInstanceIdentifier: aws.String(testRDSInstanceRows[3].UniqueAssetIdentifier),
+			DBInstanceArn:        aws.String(testRDSInstanceRows[3].SerialAssetTagNumber),
+			DBClusterIdentifier:  aws.String("test-cluster-1"),
+			Engine:               aws.String("aurora-mysql"),
+			EngineVersion:        aws.String("5.7.mysql_aurora.2.09.2"),
+			DBInstanceClass:      aws.String("db.r5.large"),
+			Endpoint: &rds.Endpoint{
+				Address: aws.String(testRDSInstanceRows[3].DNSNameOrURL),
+			},
+			PubliclyAccessible: aws.Bool(true),
+			DBSubnetGroup: &rds.DBSubnetGroup{
+				VpcId: aws.String(testRDSInstanceRows[3].VLANNetworkID),
+			},
+		},
 	},
 }
 
+var testRDSDescribeDBClustersOutputPage1 = &rds.DescribeDBClustersOutput{
+	DBClusters: []*rds.DBCluster{
+		{
+			DBClusterIdentifier: aws.String(testRDSClusterRows[0].UniqueAssetIdentifier),
+			DBClusterArn:        aws.String(testRDSClusterRows[0].SerialAssetTagNumber),
+			Engine:              aws.String("aurora-mysql"),
+			EngineVersion:       aws.String("5.7.mysql_aurora.2.09.2"),
+			EngineMode:          aws.String("provisioned"),
+			StorageEncrypted:    aws.Bool(false),
+			Endpoint:            aws.String("test-cluster-1.cluster-abcdefghijkl.us-east-1.rds.amazonaws.com"),
+			ReaderEndpoint:      aws.String("test-cluster-1.cluster-ro-abcdefghijkl.us-east-1.rds.amazonaws.com"),
+			CustomEndpoints: []*string{
+				aws.String("analytics.cluster-custom-abcdefghijkl.us-east-1.rds.amazonaws.com"),
+			},
+			DBClusterMembers: []*rds.DBClusterMember{
+				{
+					DBInstanceIdentifier: aws.String("test-db-4"),
+					IsClusterWriter:      aws.Bool(true),
+				},
+				{
+					DBInstanceIdentifier: aws.String("test-db-5"),
+					IsClusterWriter:      aws.Bool(false),
+				},
+			},
+			DBSubnetGroup: aws.String("test-subnet-group-1"),
+			TagList: []*rds.Tag{
+				{
+					Key:   aws.String("Owner"),
+					Value: aws.String("test owner"),
+				},
+			},
+		},
+	},
+	Marker: aws.String(testRDSClusterRows[0].UniqueAssetIdentifier),
+}
+
+var testRDSDescribeDBClustersOutputPage2 = &rds.DescribeDBClustersOutput{
+	DBClusters: []*rds.DBCluster{
+		{
+			DBClusterIdentifier: aws.String(testRDSClusterRows[1].UniqueAssetIdentifier),
+			DBClusterArn:        aws.String(testRDSClusterRows[1].SerialAssetTagNumber),
+			Engine:              aws.String("aurora-postgresql"),
+			EngineVersion:       aws.String("10.14"),
+			EngineMode:          aws.String("serverless"),
+			StorageEncrypted:    aws.Bool(true),
+			Endpoint:            aws.String(testRDSClusterRows[1].DNSNameOrURL),
+			DBSubnetGroup:       aws.String("test-subnet-group-2"),
+		},
+	},
+}
+
+var testRDSSubnetGroupVPCs = map[string]string{
+	"test-subnet-group-1": "vpc-12345678",
+	"test-subnet-group-2": "vpc-abcdefgh",
+}
+
 // Mocks
 type RDSMock struct {
 	rdsiface.RDSAPI
 }
 
-func (e RDSMock) DescribeDBInstances(cfg *rds.DescribeDBInstancesInput) (*rds.DescribeDBInstancesOutput, error) {
+func (e RDSMock) DescribeDBInstancesWithContext(ctx aws.Context, cfg *rds.DescribeDBInstancesInput, opts ...request.Option) (*rds.DescribeDBInstancesOutput, error) {
 	if cfg.Marker == nil {
 		return testRDSDescribeDBInstancesOutputPage1, nil
 	}
@@ -123,25 +240,48 @@ func (e RDSMock) DescribeDBInstances(cfg *rds.DescribeDBInstancesInput) (*rds.De
 	return testRDSDescribeDBInstancesOutputPage2, nil
 }
 
+func (e RDSMock) DescribeDBClustersWithContext(ctx aws.Context, cfg *rds.DescribeDBClustersInput, opts ...request.Option) (*rds.DescribeDBClustersOutput, error) {
+	if cfg.Marker == nil {
+		return testRDSDescribeDBClustersOutputPage1, nil
+	}
+
+	return testRDSDescribeDBClustersOutputPage2, nil
+}
+
+func (e RDSMock) DescribeDBSubnetGroupsWithContext(ctx aws.Context, cfg *rds.DescribeDBSubnetGroupsInput, opts ...request.Option) (*rds.DescribeDBSubnetGroupsOutput, error) {
+	return &rds.DescribeDBSubnetGroupsOutput{
+		DBSubnetGroups: []*rds.DBSubnetGroup{
+			{
+				DBSubnetGroupName: cfg.DBSubnetGroupName,
+				VpcId:             aws.String(testRDSSubnetGroupVPCs[aws.StringValue(cfg.DBSubnetGroupName)]),
+			},
+		},
+	}, nil
+}
+
 type RDSErrorMock struct {
 	rdsiface.RDSAPI
 }
 
-func (e RDSErrorMock) DescribeDBInstances(cfg *rds.DescribeDBInstancesInput) (*rds.DescribeDBInstancesOutput, error) {
+func (e RDSErrorMock) DescribeDBInstancesWithContext(ctx aws.Context, cfg *rds.DescribeDBInstancesInput, opts ...request.Option) (*rds.DescribeDBInstancesOutput, error) {
 	return &rds.DescribeDBInstancesOutput{}, testError
 }
 
+func (e RDSErrorMock) DescribeDBClustersWithContext(ctx aws.Context, cfg *rds.DescribeDBClustersInput, opts ...request.Option) (*rds.DescribeDBClustersOutput, error) {
+	return &rds.DescribeDBClustersOutput{}, testError
+}
+
 // Tests
-func TestCanLoadRDSInstances(t *testing.T) {
+func TestCanLoadRDSInstancesAndClusters(t *testing.T) {
 	d := New(logrus.New(), TestClients{RDS: RDSMock{}})
 
-	var count int
-	d.Load([]string{DefaultRegion}, []string{ServiceRDS}, func(row inventory.Row) error {
-		require.Equal(t, testRDSInstanceRows[count], row)
-		count++
+	var rows []inventory.Row
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceRDS}, func(row inventory.Row) error {
+		rows = append(rows, row)
 		return nil
 	})
-	require.Equal(t, 3, count)
+
+	require.Equal(t, append(append([]inventory.Row{}, testRDSInstanceRows...), testRDSClusterRows...), rows)
 }
 
 func TestLoadRDSInstancesLogsError(t *testing.T) {
@@ -149,7 +289,8 @@ func TestLoadRDSInstancesLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{RDS: RDSErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceRDS}, nil)
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceRDS}, nil)
 
+	require.Len(t, report.Errors, 2)
 	assertTestErrorWasLogged(t, hook.Entries)
 }
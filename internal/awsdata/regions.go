@@ -0,0 +1,87 @@
+package awsdata
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/sirupsen/logrus"
+)
+
+// regionOptInStatusNotOptedIn is the opt-in status of a region which must be enabled before it can be used
+const regionOptInStatusNotOptedIn = "not-opted-in"
+
+// EnabledRegions returns the regions enabled for the account the clients belong to, including any opt-in regions
+// which have been enabled, in alphabetical order
+func (d *AWSData) EnabledRegions(ctx context.Context) ([]string, error) {
+	if err := d.initClients(); err != nil {
+		return nil, err
+	}
+
+	ec2Svc := d.clients.GetEC2Client(DefaultRegion)
+
+	log := d.log.WithFields(logrus.Fields{
+		"region":  DefaultRegion,
+		"service": ServiceEC2,
+	})
+
+	log.Info("loading regions")
+
+	var out *ec2.DescribeRegionsOutput
+	err := d.retry(ctx, ServiceEC2, func() (err error) {
+		out, err = ec2Svc.DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{
+			AllRegions: aws.Bool(true),
+		})
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe regions: %w", err)
+	}
+
+	var regions []string
+	for _, r := range out.Regions {
+		if aws.StringValue(r.OptInStatus) == regionOptInStatusNotOptedIn {
+			log.Debugf("skipping region %s, which is not enabled", aws.StringValue(r.RegionName))
+			continue
+		}
+
+		regions = append(regions, aws.StringValue(r.RegionName))
+	}
+
+	sort.Strings(regions)
+
+	log.Infof("found %d enabled regions", len(regions))
+
+	return regions, nil
+}
+
+// serviceAvailable returns false when the AWS endpoints metadata shows the collector's service is not offered in
+// the region. Collectors without an endpoints ID, and regions missing from the metadata, such as those launched
+// since the SDK was released, are assumed to have it.
+func serviceAvailable(c Collector, region string) bool {
+	ec, ok := c.(EndpointsCollector)
+	if !ok || ec.EndpointsID() == "" {
+		return true
+	}
+
+	p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return true
+	}
+
+	if _, ok := p.Regions()[region]; !ok {
+		return true
+	}
+
+	service, ok := p.Services()[ec.EndpointsID()]
+	if !ok {
+		return false
+	}
+
+	_, ok = service.Regions()[region]
+
+	return ok
+}
@@ -0,0 +1,122 @@
+package awsdata_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+)
+
+// Test Data
+var testEC2DescribeRegionsOutput = &ec2.DescribeRegionsOutput{
+	Regions: []*ec2.Region{
+		{
+			RegionName:  aws.String(DefaultRegion),
+			OptInStatus: aws.String("opt-in-not-required"),
+		},
+		{
+			RegionName:  aws.String("af-south-1"),
+			OptInStatus: aws.String("not-opted-in"),
+		},
+		{
+			RegionName:  aws.String("ap-east-1"),
+			OptInStatus: aws.String("opted-in"),
+		},
+		{
+			RegionName:  aws.String("eu-west-2"),
+			OptInStatus: aws.String("opt-in-not-required"),
+		},
+	},
+}
+
+// testNewRegion matches the pattern of the aws partition, but is missing from the SDK endpoints metadata
+const testNewRegion = "eu-south-9"
+
+// Mocks
+type EBSNewRegionMock struct {
+	EBSMock
+}
+
+func (e EBSNewRegionMock) DescribeRegionsWithContext(ctx aws.Context, cfg *ec2.DescribeRegionsInput, opts ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	return &ec2.DescribeRegionsOutput{
+		Regions: []*ec2.Region{
+			{
+				RegionName:  aws.String(testNewRegion),
+				OptInStatus: aws.String("opted-in"),
+			},
+		},
+	}, nil
+}
+
+func (e EC2Mock) DescribeRegionsWithContext(ctx aws.Context, cfg *ec2.DescribeRegionsInput, opts ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	return testEC2DescribeRegionsOutput, nil
+}
+
+func (e EC2ErrorMock) DescribeRegionsWithContext(ctx aws.Context, cfg *ec2.DescribeRegionsInput, opts ...request.Option) (*ec2.DescribeRegionsOutput, error) {
+	return &ec2.DescribeRegionsOutput{}, testError
+}
+
+// Tests
+func TestEnabledRegionsSkipsRegionsNotOptedIn(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EC2Mock{}})
+
+	regions, err := d.EnabledRegions(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"ap-east-1", "eu-west-2", DefaultRegion}, regions)
+}
+
+func TestEnabledRegionsReturnsError(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EC2ErrorMock{}})
+
+	_, err := d.EnabledRegions(context.Background())
+	require.True(t, errors.Is(err, testError))
+}
+
+func TestLoadWithAllRegionsLoadsEnabledRegions(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EC2Mock{}, Route53: EC2Route53Mock{}}, WithAllRegions())
+
+	report := d.Load(context.Background(), nil, []string{ServiceEC2}, nil)
+
+	require.True(t, report.Complete())
+	require.Len(t, report.Rows[ServiceEC2], 3)
+	for _, region := range []string{"ap-east-1", "eu-west-2", DefaultRegion} {
+		require.Equal(t, len(testEC2InstanceRows), report.Rows[ServiceEC2][region])
+	}
+}
+
+func TestLoadWithAllRegionsReportsError(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EC2ErrorMock{}}, WithAllRegions())
+
+	report := d.Load(context.Background(), nil, []string{ServiceEC2}, nil)
+
+	require.False(t, report.Complete())
+	require.True(t, errors.Is(report.Errors[0], testError))
+	assertTestErrorWasLogged(t, hook.Entries)
+}
+
+func TestLoadWithAllRegionsLoadsRegionsMissingFromEndpoints(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EBSNewRegionMock{}}, WithAllRegions())
+
+	report := d.Load(context.Background(), nil, []string{ServiceEBS}, nil)
+
+	require.True(t, report.Complete())
+	require.Empty(t, report.Unavailable)
+	require.Equal(t, len(testEBSVolumeRows), report.Rows[ServiceEBS][testNewRegion])
+}
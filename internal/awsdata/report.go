@@ -0,0 +1,151 @@
+package awsdata
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RegionGlobal is the region used in reports and logs for global services
+const RegionGlobal = "global"
+
+// Error is an error encountered while loading data for a service in a region.
+// Errors affecting the whole run, such as ErrNoRegions, have no service or region.
+type Error struct {
+	Service string
+	Region  string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Service != "" && e.Region != "":
+		return fmt.Sprintf("%s (%s): %s", e.Service, e.Region, e.Err)
+	case e.Service != "":
+		return fmt.Sprintf("%s: %s", e.Service, e.Err)
+	case e.Region != "":
+		return fmt.Sprintf("%s: %s", e.Region, e.Err)
+	default:
+		return e.Err.Error()
+	}
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON encodes the error with its message as a string
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Service string `json:"service,omitempty"`
+		Region  string `json:"region,omitempty"`
+		Error   string `json:"error"`
+	}{
+		Service: e.Service,
+		Region:  e.Region,
+		Error:   e.Err.Error(),
+	})
+}
+
+// Report summarises the outcome of a call to Load
+type Report struct {
+	// Account is the account the data was loaded from, when one was given with WithAccount
+	Account *Account `json:"account,omitempty"`
+
+	// Rows holds the number of rows loaded, keyed by service then region
+	Rows map[string]map[string]int `json:"rows"`
+
+	// Filtered holds the number of rows left out by the resource and tag filters, keyed by service then region
+	Filtered map[string]map[string]int `json:"filtered"`
+
+	// Unavailable holds the regions skipped because the service is not offered there, keyed by service
+	Unavailable map[string][]string `json:"unavailable"`
+
+	// Errors holds every error encountered, in the order they were reported
+	Errors []*Error `json:"errors"`
+
+	// Retries holds the number of throttled requests that were retried, keyed by the service whose API was called,
+	// so retried EC2 API requests are all counted against ec2
+	Retries map[string]int `json:"retries"`
+}
+
+func newReport() *Report {
+	return &Report{
+		Rows:        make(map[string]map[string]int),
+		Filtered:    make(map[string]map[string]int),
+		Unavailable: make(map[string][]string),
+		Errors:      []*Error{},
+		Retries:     make(map[string]int),
+	}
+}
+
+// Complete returns true when no errors were encountered, meaning the inventory covers every service and region requested
+func (r *Report) Complete() bool {
+	return len(r.Errors) == 0
+}
+
+// Count returns the total number of rows loaded
+func (r *Report) Count() (count int) {
+	for _, regions := range r.Rows {
+		for _, n := range regions {
+			count += n
+		}
+	}
+
+	return
+}
+
+// FilteredCount returns the total number of rows left out by the filters
+func (r *Report) FilteredCount() (count int) {
+	for _, regions := range r.Filtered {
+		for _, n := range regions {
+			count += n
+		}
+	}
+
+	return
+}
+
+// MarshalJSON encodes the report along with its totals
+func (r *Report) MarshalJSON() ([]byte, error) {
+	type report Report
+	return json.Marshal(struct {
+		Complete      bool `json:"complete"`
+		Count         int  `json:"count"`
+		FilteredCount int  `json:"filtered_count"`
+		report
+	}{
+		Complete:      r.Complete(),
+		Count:         r.Count(),
+		FilteredCount: r.FilteredCount(),
+		report:        report(*r),
+	})
+}
+
+func (r *Report) addRow(service, region string) {
+	if r.Rows[service] == nil {
+		r.Rows[service] = make(map[string]int)
+	}
+
+	r.Rows[service][region]++
+}
+
+func (r *Report) addFiltered(service, region string) {
+	if r.Filtered[service] == nil {
+		r.Filtered[service] = make(map[string]int)
+	}
+
+	r.Filtered[service][region]++
+}
+
+func (r *Report) addUnavailable(service, region string) {
+	r.Unavailable[service] = append(r.Unavailable[service], region)
+}
+
+func (r *Report) addError(service, region string, err error) {
+	r.Errors = append(r.Errors, &Error{
+		Service: service,
+		Region:  region,
+		Err:     err,
+	})
+}
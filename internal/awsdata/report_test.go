@@ -0,0 +1,123 @@
+package awsdata_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+// Tests
+func TestLoadReportsRowsPerServiceAndRegion(t *testing.T) {
+	d := New(logrus.New(), TestClients{DynamoDB: DynamoDBMock{}, IAM: IAMMock{}})
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB, ServiceIAM}, nil)
+
+	require.True(t, report.Complete())
+	require.Equal(t, 3, report.Rows[ServiceDynamoDB][DefaultRegion])
+	require.Equal(t, len(testIAMRows), report.Rows[ServiceIAM][RegionGlobal])
+	require.Equal(t, 3+len(testIAMRows), report.Count())
+}
+
+func TestLoadReportsErrorsPerServiceAndRegion(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{DynamoDB: DynamoDBErrorMock{}})
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB}, nil)
+
+	require.False(t, report.Complete())
+	require.Len(t, report.Errors, 1)
+	require.Equal(t, ServiceDynamoDB, report.Errors[0].Service)
+	require.Equal(t, DefaultRegion, report.Errors[0].Region)
+	require.True(t, errors.Is(report.Errors[0], testError))
+}
+
+func TestLoadReportsErrNoRegions(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{})
+
+	report := d.Load(context.Background(), []string{}, []string{ServiceEC2}, nil)
+
+	require.False(t, report.Complete())
+	require.True(t, errors.Is(report.Errors[0], ErrNoRegions))
+}
+
+func TestLoadReportsErrInvalidRegion(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{})
+
+	report := d.Load(context.Background(), []string{"test-region"}, []string{ServiceEC2}, nil)
+
+	require.False(t, report.Complete())
+	require.True(t, errors.Is(report.Errors[0], ErrInvalidRegion))
+}
+
+func TestLoadReportsProcessRowErrors(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{DynamoDB: DynamoDBMock{}})
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB}, func(row inventory.Row) error {
+		return testError
+	})
+
+	require.False(t, report.Complete())
+	require.Len(t, report.Errors, 3)
+	require.Equal(t, 0, report.Count())
+}
+
+func TestLoadReportsRegionsWhereServiceIsNotAvailable(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{CodeCommit: CodeCommitMock{}})
+
+	report := d.Load(context.Background(), []string{DefaultRegion, "af-south-1"}, []string{ServiceCodeCommit}, nil)
+
+	require.True(t, report.Complete())
+	require.Equal(t, map[string]int{DefaultRegion: len(testCodeCommitRepositoryRows)}, report.Rows[ServiceCodeCommit])
+	require.Equal(t, map[string][]string{ServiceCodeCommit: {"af-south-1"}}, report.Unavailable)
+
+	var logged bool
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == "service not available in region, skipping" && entry.Data["region"] == "af-south-1" {
+			logged = true
+		}
+	}
+	require.True(t, logged, "expected the unavailable region to be logged")
+}
+
+func TestReportCanBeEncodedAsJSON(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{DynamoDB: DynamoDBErrorMock{}})
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB}, nil)
+
+	b, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	var actual map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &actual))
+
+	require.Equal(t, false, actual["complete"])
+	require.Equal(t, float64(0), actual["count"])
+	require.Equal(t, float64(0), actual["filtered_count"])
+	require.Equal(t, map[string]interface{}{}, actual["unavailable"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"service": ServiceDynamoDB,
+			"region":  DefaultRegion,
+			"error":   "failed to list tables: " + testError.Error(),
+		},
+	}, actual["errors"])
+}
@@ -0,0 +1,64 @@
+package awsdata
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a throttled request is retried when no other limit is given
+	DefaultMaxRetries = 5
+
+	// DefaultRetryDelay is the base delay before retrying a throttled request when no other delay is given
+	DefaultRetryDelay = 500 * time.Millisecond
+
+	// maxRetryDelay caps the delay before any single retry
+	maxRetryDelay = 30 * time.Second
+)
+
+// retry calls fn until it succeeds, fails with an error other than throttling, or runs out of retries.
+// Retries back off exponentially with full jitter and are counted against the service in the report.
+// The service is the one whose API fn calls, which is not always the service loading the data: requests to the
+// EC2 API are made as ServiceEC2 whichever service they are for, since they share the same throttling.
+// Each call to fn holds room in the pool for the service, so the concurrency limits bound the AWS API requests
+// in flight. Since every request goes through retry, nothing else may hold room in the pool, or a goroutine
+// waiting on its own requests could block the ones it started.
+func (d *AWSData) retry(ctx context.Context, service string, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		if err := d.pool.acquire(ctx, service); err != nil {
+			return err
+		}
+		err := fn()
+		d.pool.release(service)
+		if err == nil || !request.IsErrorThrottle(err) || attempt >= d.maxRetries {
+			return err
+		}
+
+		d.retriesLock.Lock()
+		d.retries[service]++
+		d.retriesLock.Unlock()
+
+		select {
+		case <-time.After(backoff(d.retryDelay, attempt)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// backoff returns a random delay of up to base * 2^attempt, capped at maxRetryDelay
+func backoff(base time.Duration, attempt int) time.Duration {
+	delay := maxRetryDelay
+	if attempt < 16 && base<<uint(attempt) < maxRetryDelay {
+		delay = base << uint(attempt)
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
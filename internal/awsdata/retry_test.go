@@ -0,0 +1,157 @@
+package awsdata_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+var testThrottlingError = awserr.New("Throttling", "Rate exceeded", nil)
+
+// throttles counts down the requests to throttle, which may be made concurrently
+type throttles struct {
+	sync.Mutex
+	n int
+}
+
+// next returns true when the next request should be throttled
+func (t *throttles) next() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.n > 0 {
+		t.n--
+		return true
+	}
+
+	return false
+}
+
+// Mocks
+type DynamoDBThrottleMock struct {
+	DynamoDBMock
+
+	throttles *throttles
+}
+
+func (e DynamoDBThrottleMock) ListTablesWithContext(ctx aws.Context, cfg *dynamodb.ListTablesInput, opts ...request.Option) (*dynamodb.ListTablesOutput, error) {
+	if e.throttles.next() {
+		return nil, testThrottlingError
+	}
+
+	return e.DynamoDBMock.ListTablesWithContext(ctx, cfg, opts...)
+}
+
+type DynamoDBDescribeThrottleMock struct {
+	DynamoDBMock
+
+	throttles *throttles
+}
+
+func (e DynamoDBDescribeThrottleMock) DescribeTableWithContext(ctx aws.Context, cfg *dynamodb.DescribeTableInput, opts ...request.Option) (*dynamodb.DescribeTableOutput, error) {
+	if e.throttles.next() {
+		return nil, testThrottlingError
+	}
+
+	return e.DynamoDBMock.DescribeTableWithContext(ctx, cfg, opts...)
+}
+
+type S3TaggingThrottleMock struct {
+	S3Mock
+
+	throttles *throttles
+}
+
+func (e S3TaggingThrottleMock) GetBucketTaggingWithContext(ctx aws.Context, cfg *s3.GetBucketTaggingInput, opts ...request.Option) (*s3.GetBucketTaggingOutput, error) {
+	if e.throttles.next() {
+		return nil, testThrottlingError
+	}
+
+	return e.S3Mock.GetBucketTaggingWithContext(ctx, cfg, opts...)
+}
+
+// Tests
+func TestLoadRetriesThrottledPages(t *testing.T) {
+	d := New(logrus.New(), TestClients{DynamoDB: DynamoDBThrottleMock{throttles: &throttles{n: 2}}}, WithRetryDelay(time.Millisecond))
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB}, nil)
+
+	require.True(t, report.Complete())
+	require.Equal(t, 3, report.Count())
+	require.Equal(t, 2, report.Retries[ServiceDynamoDB])
+}
+
+func TestLoadRetriesThrottledItems(t *testing.T) {
+	d := New(logrus.New(), TestClients{DynamoDB: DynamoDBDescribeThrottleMock{throttles: &throttles{n: 2}}}, WithRetryDelay(time.Millisecond))
+
+	var rows []inventory.Row
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	require.True(t, report.Complete())
+	require.Equal(t, testDynamoDBTableRows, rows)
+	require.Equal(t, 2, report.Retries[ServiceDynamoDB])
+}
+
+func TestLoadRetriesThrottledTags(t *testing.T) {
+	d := New(logrus.New(), TestClients{S3: S3TaggingThrottleMock{throttles: &throttles{n: 1}}}, WithRetryDelay(time.Millisecond))
+
+	var rows []inventory.Row
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceS3}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	require.True(t, report.Complete())
+	require.Equal(t, testS3Rows, rows)
+	require.Equal(t, 1, report.Retries[ServiceS3])
+}
+
+func TestLoadGivesUpAfterMaxRetries(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{DynamoDB: DynamoDBThrottleMock{throttles: &throttles{n: 5}}}, WithMaxRetries(2), WithRetryDelay(time.Millisecond))
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB}, nil)
+
+	require.False(t, report.Complete())
+	require.True(t, errors.Is(report.Errors[0], testThrottlingError))
+	require.Equal(t, 2, report.Retries[ServiceDynamoDB])
+	assertErrorWasLogged(t, hook.Entries, testThrottlingError)
+}
+
+func TestLoadDoesNotRetryOtherErrors(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{DynamoDB: DynamoDBErrorMock{}}, WithRetryDelay(time.Millisecond))
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceDynamoDB}, nil)
+
+	require.False(t, report.Complete())
+	require.Equal(t, 0, report.Retries[ServiceDynamoDB])
+}
@@ -1,9 +1,11 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
@@ -19,9 +21,13 @@ const (
 	ServiceS3 string = "s3"
 )
 
-func (d *AWSData) loadS3Buckets(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceS3, s3.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadS3Buckets(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadS3Buckets(ctx context.Context, region string) {
 	s3Svc := d.clients.GetS3Client(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -36,9 +42,13 @@ func (d *AWSData) loadS3Buckets(region string) {
 		partition = p.ID()
 	}
 
-	out, err := s3Svc.ListBuckets(&s3.ListBucketsInput{})
+	var out *s3.ListBucketsOutput
+	err := d.retry(ctx, ServiceS3, func() (err error) {
+		out, err = s3Svc.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+		return
+	})
 	if err != nil {
-		log.Errorf("Failed to list buckets: %s", err)
+		d.AddError(ServiceS3, region, fmt.Errorf("failed to list buckets: %w", err))
 		return
 	}
 
@@ -46,20 +56,24 @@ func (d *AWSData) loadS3Buckets(region string) {
 
 	for _, b := range out.Buckets {
 		d.wg.Add(1)
-		go d.processS3Bucket(log, s3Svc, b, partition, region)
+		go d.processS3Bucket(ctx, log, s3Svc, b, partition, region)
 	}
 
 	log.Info("finished processing data")
 }
 
-func (d *AWSData) processS3Bucket(log *logrus.Entry, s3Svc s3iface.S3API, bucket *s3.Bucket, partition string, region string) {
+func (d *AWSData) processS3Bucket(ctx context.Context, log *logrus.Entry, s3Svc s3iface.S3API, bucket *s3.Bucket, partition string, region string) {
 	defer d.wg.Done()
 
-	outLocation, err := s3Svc.GetBucketLocation(&s3.GetBucketLocationInput{
-		Bucket: bucket.Name,
+	var outLocation *s3.GetBucketLocationOutput
+	err := d.retry(ctx, ServiceS3, func() (err error) {
+		outLocation, err = s3Svc.GetBucketLocationWithContext(ctx, &s3.GetBucketLocationInput{
+			Bucket: bucket.Name,
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to get bucket location for %s: %s", aws.StringValue(bucket.Name), err)
+		d.AddError(ServiceS3, region, fmt.Errorf("failed to get bucket location for %s: %w", aws.StringValue(bucket.Name), err))
 		return
 	}
 
@@ -68,11 +82,29 @@ func (d *AWSData) processS3Bucket(log *logrus.Entry, s3Svc s3iface.S3API, bucket
 		return
 	}
 
-	d.rows <- inventory.Row{
+	var tags map[string]string
+	var outTagging *s3.GetBucketTaggingOutput
+	err = d.retry(ctx, ServiceS3, func() (err error) {
+		outTagging, err = s3Svc.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{
+			Bucket: bucket.Name,
+		})
+		return
+	})
+	if err != nil {
+		// Buckets without tags return an error rather than an empty tag set
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "NoSuchTagSet" {
+			d.AddError(ServiceS3, region, fmt.Errorf("failed to get tags for %s: %w", aws.StringValue(bucket.Name), err))
+		}
+	} else {
+		tags = tagMap(len(outTagging.TagSet), func(i int) (*string, *string) { return outTagging.TagSet[i].Key, outTagging.TagSet[i].Value })
+	}
+
+	d.AddRow(ServiceS3, region, inventory.Row{
 		UniqueAssetIdentifier: aws.StringValue(bucket.Name),
 		Virtual:               true,
 		Location:              region,
 		AssetType:             AssetTypeS3Bucket,
 		SerialAssetTagNumber:  fmt.Sprintf("arn:%s:s3:::%s", partition, aws.StringValue(bucket.Name)),
-	}
+		Tags:                  tags,
+	})
 }
@@ -1,10 +1,13 @@
 package awsdata_test
 
 import (
+	"context"
 	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/sirupsen/logrus"
@@ -22,6 +25,9 @@ var testS3Rows = []inventory.Row{
 		Location:              DefaultRegion,
 		AssetType:             AssetTypeS3Bucket,
 		SerialAssetTagNumber:  "arn:aws:s3:::test-bucket-1",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier: "test-bucket-2",
@@ -63,23 +69,38 @@ type S3Mock struct {
 	s3iface.S3API
 }
 
-func (e S3Mock) ListBuckets(cfg *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+func (e S3Mock) ListBucketsWithContext(ctx aws.Context, cfg *s3.ListBucketsInput, opts ...request.Option) (*s3.ListBucketsOutput, error) {
 	return testS3ListBucketsOutput, nil
 }
 
-func (e S3Mock) GetBucketLocation(cfg *s3.GetBucketLocationInput) (*s3.GetBucketLocationOutput, error) {
+func (e S3Mock) GetBucketLocationWithContext(ctx aws.Context, cfg *s3.GetBucketLocationInput, opts ...request.Option) (*s3.GetBucketLocationOutput, error) {
 	return testS3GetBucketLocationOutput, nil
 }
 
+func (e S3Mock) GetBucketTaggingWithContext(ctx aws.Context, cfg *s3.GetBucketTaggingInput, opts ...request.Option) (*s3.GetBucketTaggingOutput, error) {
+	if aws.StringValue(cfg.Bucket) != testS3Rows[0].UniqueAssetIdentifier {
+		return &s3.GetBucketTaggingOutput{}, awserr.New("NoSuchTagSet", "The TagSet does not exist", nil)
+	}
+
+	return &s3.GetBucketTaggingOutput{
+		TagSet: []*s3.Tag{
+			{
+				Key:   aws.String("Owner"),
+				Value: aws.String("test owner"),
+			},
+		},
+	}, nil
+}
+
 type S3ErrorMock struct {
 	s3iface.S3API
 }
 
-func (e S3ErrorMock) ListBuckets(cfg *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+func (e S3ErrorMock) ListBucketsWithContext(ctx aws.Context, cfg *s3.ListBucketsInput, opts ...request.Option) (*s3.ListBucketsOutput, error) {
 	return &s3.ListBucketsOutput{}, testError
 }
 
-func (e S3ErrorMock) GetBucketLocation(cfg *s3.GetBucketLocationInput) (*s3.GetBucketLocationOutput, error) {
+func (e S3ErrorMock) GetBucketLocationWithContext(ctx aws.Context, cfg *s3.GetBucketLocationInput, opts ...request.Option) (*s3.GetBucketLocationOutput, error) {
 	return &s3.GetBucketLocationOutput{}, testError
 }
 
@@ -88,7 +109,7 @@ func TestCanLoadS3Buckets(t *testing.T) {
 	d := New(logrus.New(), TestClients{S3: S3Mock{}})
 
 	var rows []inventory.Row
-	d.Load([]string{DefaultRegion}, []string{ServiceS3}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceS3}, func(row inventory.Row) error {
 		rows = append(rows, row)
 		return nil
 	})
@@ -109,7 +130,7 @@ func TestLoadS3BucketsLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{S3: S3ErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceS3}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceS3}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
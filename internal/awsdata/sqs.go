@@ -1,6 +1,7 @@
 package awsdata
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -19,9 +20,13 @@ const (
 	ServiceSQS string = "sqs"
 )
 
-func (d *AWSData) loadSQSQueues(region string) {
-	defer d.wg.Done()
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceSQS, sqs.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadSQSQueues(ctx, region)
+	}))
+}
 
+func (d *AWSData) loadSQSQueues(ctx context.Context, region string) {
 	sqsSvc := d.clients.GetSQSClient(region)
 
 	log := d.log.WithFields(logrus.Fields{
@@ -35,10 +40,13 @@ func (d *AWSData) loadSQSQueues(region string) {
 	done := false
 	params := &sqs.ListQueuesInput{}
 	for !done {
-		out, err := sqsSvc.ListQueues(params)
-
+		var out *sqs.ListQueuesOutput
+		err := d.retry(ctx, ServiceSQS, func() (err error) {
+			out, err = sqsSvc.ListQueuesWithContext(ctx, params)
+			return
+		})
 		if err != nil {
-			log.Errorf("failed to list queues: %s", err)
+			d.AddError(ServiceSQS, region, fmt.Errorf("failed to list queues: %w", err))
 			return
 		}
 
@@ -55,29 +63,47 @@ func (d *AWSData) loadSQSQueues(region string) {
 
 	for _, q := range queueUrls {
 		d.wg.Add(1)
-		go d.processSQSQueue(log, sqsSvc, q, region)
+		go d.processSQSQueue(ctx, log, sqsSvc, q, region)
 	}
 
 	log.Info("finished processing data")
 }
 
-func (d *AWSData) processSQSQueue(log *logrus.Entry, sqsSvc sqsiface.SQSAPI, queueURL *string, region string) {
+func (d *AWSData) processSQSQueue(ctx context.Context, log *logrus.Entry, sqsSvc sqsiface.SQSAPI, queueURL *string, region string) {
 	defer d.wg.Done()
 
-	out, err := sqsSvc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
-		QueueUrl: queueURL,
-		AttributeNames: []*string{
-			aws.String(sqs.QueueAttributeNameApproximateNumberOfMessages),
-			aws.String(sqs.QueueAttributeNameApproximateNumberOfMessagesNotVisible),
-			aws.String(sqs.QueueAttributeNameQueueArn),
-		},
+	var out *sqs.GetQueueAttributesOutput
+	err := d.retry(ctx, ServiceSQS, func() (err error) {
+		out, err = sqsSvc.GetQueueAttributesWithContext(ctx, &sqs.GetQueueAttributesInput{
+			QueueUrl: queueURL,
+			AttributeNames: []*string{
+				aws.String(sqs.QueueAttributeNameApproximateNumberOfMessages),
+				aws.String(sqs.QueueAttributeNameApproximateNumberOfMessagesNotVisible),
+				aws.String(sqs.QueueAttributeNameQueueArn),
+			},
+		})
+		return
 	})
 	if err != nil {
-		log.Errorf("failed to get queue attributes for %s: %s", aws.StringValue(queueURL), err)
+		d.AddError(ServiceSQS, region, fmt.Errorf("failed to get queue attributes for %s: %w", aws.StringValue(queueURL), err))
+		return
+	}
+
+	var tags map[string]string
+	var outTags *sqs.ListQueueTagsOutput
+	err = d.retry(ctx, ServiceSQS, func() (err error) {
+		outTags, err = sqsSvc.ListQueueTagsWithContext(ctx, &sqs.ListQueueTagsInput{
+			QueueUrl: queueURL,
+		})
 		return
+	})
+	if err != nil {
+		d.AddError(ServiceSQS, region, fmt.Errorf("failed to list tags for %s: %w", aws.StringValue(queueURL), err))
+	} else {
+		tags = stringMapTags(outTags.Tags)
 	}
 
-	d.rows <- inventory.Row{
+	d.AddRow(ServiceSQS, region, inventory.Row{
 		UniqueAssetIdentifier: (*queueURL)[strings.LastIndex(aws.StringValue(queueURL), "/")+1:],
 		Virtual:               true,
 		DNSNameOrURL:          aws.StringValue(queueURL),
@@ -85,5 +111,6 @@ func (d *AWSData) processSQSQueue(log *logrus.Entry, sqsSvc sqsiface.SQSAPI, que
 		AssetType:             AssetTypeSQSQueue,
 		Comments:              fmt.Sprintf("%s, %s", aws.StringValue(out.Attributes[sqs.QueueAttributeNameApproximateNumberOfMessages]), aws.StringValue(out.Attributes[sqs.QueueAttributeNameApproximateNumberOfMessagesNotVisible])),
 		SerialAssetTagNumber:  aws.StringValue(out.Attributes[sqs.QueueAttributeNameQueueArn]),
-	}
+		Tags:                  tags,
+	})
 }
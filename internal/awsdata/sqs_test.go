@@ -1,10 +1,12 @@
 package awsdata_test
 
 import (
+	"context"
 	"sort"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/sirupsen/logrus"
@@ -24,6 +26,9 @@ var testSQSQueueRows = []inventory.Row{
 		AssetType:             AssetTypeSQSQueue,
 		Comments:              "100, 0",
 		SerialAssetTagNumber:  "arn:aws:sqs:us-east-1:123456789012:TestQueue1",
+		Tags: map[string]string{
+			"Owner": "test owner",
+		},
 	},
 	{
 		UniqueAssetIdentifier: "TestQueue2",
@@ -65,7 +70,7 @@ type SQSMock struct {
 	sqsiface.SQSAPI
 }
 
-func (e SQSMock) ListQueues(cfg *sqs.ListQueuesInput) (*sqs.ListQueuesOutput, error) {
+func (e SQSMock) ListQueuesWithContext(ctx aws.Context, cfg *sqs.ListQueuesInput, opts ...request.Option) (*sqs.ListQueuesOutput, error) {
 	if cfg.NextToken == nil {
 		return testSQSListQueuesOutputPage1, nil
 	}
@@ -73,7 +78,7 @@ func (e SQSMock) ListQueues(cfg *sqs.ListQueuesInput) (*sqs.ListQueuesOutput, er
 	return testSQSListQueuesOutputPage2, nil
 }
 
-func (e SQSMock) GetQueueAttributes(cfg *sqs.GetQueueAttributesInput) (*sqs.GetQueueAttributesOutput, error) {
+func (e SQSMock) GetQueueAttributesWithContext(ctx aws.Context, cfg *sqs.GetQueueAttributesInput, opts ...request.Option) (*sqs.GetQueueAttributesOutput, error) {
 	var row int
 	var numMessages, numMessagesNotVisible string
 	switch aws.StringValue(cfg.QueueUrl) {
@@ -99,15 +104,27 @@ func (e SQSMock) GetQueueAttributes(cfg *sqs.GetQueueAttributesInput) (*sqs.GetQ
 	}, nil
 }
 
+func (e SQSMock) ListQueueTagsWithContext(ctx aws.Context, cfg *sqs.ListQueueTagsInput, opts ...request.Option) (*sqs.ListQueueTagsOutput, error) {
+	if aws.StringValue(cfg.QueueUrl) != testSQSQueueRows[0].DNSNameOrURL {
+		return &sqs.ListQueueTagsOutput{}, nil
+	}
+
+	return &sqs.ListQueueTagsOutput{
+		Tags: map[string]*string{
+			"Owner": aws.String("test owner"),
+		},
+	}, nil
+}
+
 type SQSErrorMock struct {
 	sqsiface.SQSAPI
 }
 
-func (e SQSErrorMock) ListQueues(cfg *sqs.ListQueuesInput) (*sqs.ListQueuesOutput, error) {
+func (e SQSErrorMock) ListQueuesWithContext(ctx aws.Context, cfg *sqs.ListQueuesInput, opts ...request.Option) (*sqs.ListQueuesOutput, error) {
 	return &sqs.ListQueuesOutput{}, testError
 }
 
-func (e SQSErrorMock) GetQueueAttributes(cfg *sqs.GetQueueAttributesInput) (*sqs.GetQueueAttributesOutput, error) {
+func (e SQSErrorMock) GetQueueAttributesWithContext(ctx aws.Context, cfg *sqs.GetQueueAttributesInput, opts ...request.Option) (*sqs.GetQueueAttributesOutput, error) {
 	return &sqs.GetQueueAttributesOutput{}, testError
 }
 
@@ -116,7 +133,7 @@ func TestCanLoadSQSQueues(t *testing.T) {
 	d := New(logrus.New(), TestClients{SQS: SQSMock{}})
 
 	var rows []inventory.Row
-	d.Load([]string{DefaultRegion}, []string{ServiceSQS}, func(row inventory.Row) error {
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceSQS}, func(row inventory.Row) error {
 		rows = append(rows, row)
 		return nil
 	})
@@ -137,7 +154,7 @@ func TestLoadSQSQueuesLogsError(t *testing.T) {
 
 	d := New(logger, TestClients{SQS: SQSErrorMock{}})
 
-	d.Load([]string{DefaultRegion}, []string{ServiceSQS}, nil)
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceSQS}, nil)
 
 	assertTestErrorWasLogged(t, hook.Entries)
 }
@@ -0,0 +1,46 @@
+package awsdata
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+// stringMapTags returns tags given as a map of string pointers, as by Lambda and SQS, or nil when there are none
+func stringMapTags(tags map[string]*string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return aws.StringValueMap(tags)
+}
+
+// tagMap returns the n tags of a resource as a map, reading the key and value of each with the tag function,
+// or nil when there are none. Each service has its own tag type, which this saves converting one by one.
+func tagMap(n int, tag func(i int) (key, value *string)) map[string]string {
+	if n == 0 {
+		return nil
+	}
+
+	m := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		key, value := tag(i)
+		m[aws.StringValue(key)] = aws.StringValue(value)
+	}
+
+	return m
+}
+
+// applyTagMapping sets the fields of the row from the tags mapped to them, keeping the collected value of any field
+// whose tag the resource does not have
+func (d *AWSData) applyTagMapping(row *inventory.Row) {
+	for field, key := range d.tagMapping {
+		value, ok := row.Tags[key]
+		if !ok || value == "" {
+			continue
+		}
+
+		if err := row.SetField(field, value); err != nil {
+			d.log.Warningf("failed to set %s of %s from tag %s: %s", field, row.UniqueAssetIdentifier, key, err)
+		}
+	}
+}
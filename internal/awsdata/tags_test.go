@@ -0,0 +1,118 @@
+package awsdata_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/s3"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+// Mocks
+type S3TaggingErrorMock struct {
+	S3Mock
+}
+
+func (e S3TaggingErrorMock) GetBucketTaggingWithContext(ctx aws.Context, cfg *s3.GetBucketTaggingInput, opts ...request.Option) (*s3.GetBucketTaggingOutput, error) {
+	return nil, testError
+}
+
+type IAMTaggingErrorMock struct {
+	IAMMock
+}
+
+func (e IAMTaggingErrorMock) ListUserTagsWithContext(ctx aws.Context, cfg *iam.ListUserTagsInput, opts ...request.Option) (*iam.ListUserTagsOutput, error) {
+	return nil, testError
+}
+
+type CloudFrontTaggingErrorMock struct {
+	CloudFrontMock
+}
+
+func (e CloudFrontTaggingErrorMock) ListTagsForResourceWithContext(ctx aws.Context, cfg *cloudfront.ListTagsForResourceInput, opts ...request.Option) (*cloudfront.ListTagsForResourceOutput, error) {
+	return nil, testError
+}
+
+// Tests
+func TestLoadSetsFieldsFromMappedTags(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{S3: S3Mock{}}, WithTagMapping(map[string]string{
+		"system_administrator_owner": "Owner",
+		"function":                   "Function",
+	}))
+
+	var rows []inventory.Row
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceS3}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	require.Empty(t, report.Errors)
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	expected := append([]inventory.Row(nil), testS3Rows...)
+	expected[0].SystemAdministratorOwner = "test owner"
+
+	require.Equal(t, expected, rows)
+}
+
+func TestLoadCatchesTagMappedToUnknownField(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{}, WithTagMapping(map[string]string{
+		"owner": "Owner",
+	}))
+
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceS3}, nil)
+
+	assertErrorWasLogged(t, hook.Entries, errors.New("invalid tag mapping: unknown field owner"))
+}
+
+func TestLoadReportsTagsWhichFailToLoad(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{S3: S3TaggingErrorMock{}})
+
+	var rows []inventory.Row
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceS3}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	require.False(t, report.Complete())
+	require.Len(t, rows, len(testS3Rows))
+	for _, row := range rows {
+		require.Nil(t, row.Tags)
+	}
+	assertTestErrorWasLogged(t, hook.Entries)
+}
+
+func TestLoadReportsTagsWhichFailToLoadForGlobalServicesAsGlobal(t *testing.T) {
+	logger, _ := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{IAM: IAMTaggingErrorMock{}, CloudFront: CloudFrontTaggingErrorMock{}})
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceIAM, ServiceCloudFront}, nil)
+
+	require.False(t, report.Complete())
+	require.NotEmpty(t, report.Errors)
+	for _, err := range report.Errors {
+		require.Equal(t, RegionGlobal, err.Region, err.Error())
+	}
+	require.NotContains(t, report.Rows[ServiceIAM], DefaultRegion)
+	require.NotContains(t, report.Rows[ServiceCloudFront], DefaultRegion)
+}
@@ -0,0 +1,453 @@
+package awsdata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/manywho/awsinventory/internal/inventory"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AssetTypeVPC is the value used in the AssetType field when fetching VPCs
+	AssetTypeVPC string = "VPC"
+
+	// AssetTypeVPCSubnet is the value used in the AssetType field when fetching VPC subnets
+	AssetTypeVPCSubnet string = "VPC Subnet"
+
+	// AssetTypeNATGateway is the value used in the AssetType field when fetching NAT gateways
+	AssetTypeNATGateway string = "NAT Gateway"
+
+	// AssetTypeInternetGateway is the value used in the AssetType field when fetching internet gateways
+	AssetTypeInternetGateway string = "Internet Gateway"
+
+	// AssetTypeEgressOnlyInternetGateway is the value used in the AssetType field when fetching egress-only
+	// internet gateways
+	AssetTypeEgressOnlyInternetGateway string = "Egress-Only Internet Gateway"
+
+	// AssetTypeVPCEndpoint is the value used in the AssetType field when fetching VPC endpoints
+	AssetTypeVPCEndpoint string = "VPC Endpoint"
+
+	// AssetTypeTransitGatewayAttachment is the value used in the AssetType field when fetching Transit Gateway
+	// attachments
+	AssetTypeTransitGatewayAttachment string = "Transit Gateway Attachment"
+
+	// ServiceVPC is the key for the VPC service
+	ServiceVPC string = "vpc"
+)
+
+// vpcStateDeleted is the state of NAT gateways, VPC endpoints and Transit Gateway attachments which have been deleted
+// but are still listed for a while
+const vpcStateDeleted = "deleted"
+
+func init() {
+	RegisterCollector(NewEndpointsCollector(ServiceVPC, ec2.EndpointsID, false, func(ctx context.Context, d *AWSData, region string) {
+		d.loadVPCNetworking(ctx, region)
+	}))
+}
+
+// loadVPCNetworking loads the networking resources of the VPCs in the region. These all come from the EC2 API, so
+// their requests are retried and limited as EC2 requests.
+func (d *AWSData) loadVPCNetworking(ctx context.Context, region string) {
+	ec2Svc := d.clients.GetEC2Client(region)
+
+	log := d.log.WithFields(logrus.Fields{
+		"region":  region,
+		"service": ServiceVPC,
+	})
+
+	log.Info("loading data")
+
+	var partition string
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		partition = p.ID()
+	}
+
+	// NAT gateways and egress-only internet gateways do not give their owner, so their ARNs use the account of
+	// the security groups as other services do
+	var accountID string
+	var out *ec2.DescribeSecurityGroupsOutput
+	err := d.retry(ctx, ServiceEC2, func() (err error) {
+		out, err = ec2Svc.DescribeSecurityGroupsWithContext(ctx, &ec2.DescribeSecurityGroupsInput{
+			MaxResults: aws.Int64(5),
+		})
+		return
+	})
+	if err != nil {
+		d.AddError(ServiceVPC, region, fmt.Errorf("failed to load account id from security groups: %w", err))
+	} else if len(out.SecurityGroups) > 0 {
+		accountID = aws.StringValue(out.SecurityGroups[0].OwnerId)
+	}
+
+	d.loadVPCs(ctx, ec2Svc, region, partition)
+	d.loadVPCSubnets(ctx, ec2Svc, region)
+	d.loadNATGateways(ctx, ec2Svc, region, partition, accountID)
+	d.loadInternetGateways(ctx, ec2Svc, region, partition)
+	d.loadEgressOnlyInternetGateways(ctx, ec2Svc, region, partition, accountID)
+	d.loadVPCEndpoints(ctx, ec2Svc, region, partition)
+	d.loadTransitGatewayAttachments(ctx, ec2Svc, region, partition)
+
+	log.Info("finished processing data")
+}
+
+func (d *AWSData) loadVPCs(ctx context.Context, ec2Svc ec2iface.EC2API, region, partition string) {
+	var vpcs []*ec2.Vpc
+	done := false
+	params := &ec2.DescribeVpcsInput{}
+	for !done {
+		var out *ec2.DescribeVpcsOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeVpcsWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceVPC, region, fmt.Errorf("failed to describe vpcs: %w", err))
+			return
+		}
+
+		vpcs = append(vpcs, out.Vpcs...)
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	for _, v := range vpcs {
+		var cidrs []string
+		for _, a := range v.CidrBlockAssociationSet {
+			cidrs = append(cidrs, aws.StringValue(a.CidrBlock))
+		}
+		for _, a := range v.Ipv6CidrBlockAssociationSet {
+			cidrs = append(cidrs, aws.StringValue(a.Ipv6CidrBlock))
+		}
+
+		var comments string
+		if aws.BoolValue(v.IsDefault) {
+			comments = "Default VPC"
+		}
+
+		d.AddRow(ServiceVPC, region, inventory.Row{
+			UniqueAssetIdentifier: aws.StringValue(v.VpcId),
+			IPv4orIPv6Address:     strings.Join(cidrs, "\n"),
+			Virtual:               true,
+			Location:              region,
+			AssetType:             AssetTypeVPC,
+			Function:              ec2Name(v.Tags),
+			Comments:              comments,
+			SerialAssetTagNumber:  ec2ARN(partition, region, aws.StringValue(v.OwnerId), "vpc", aws.StringValue(v.VpcId)),
+			VLANNetworkID:         aws.StringValue(v.VpcId),
+			Tags:                  ec2Tags(v.Tags),
+		})
+	}
+}
+
+func (d *AWSData) loadVPCSubnets(ctx context.Context, ec2Svc ec2iface.EC2API, region string) {
+	var subnets []*ec2.Subnet
+	done := false
+	params := &ec2.DescribeSubnetsInput{}
+	for !done {
+		var out *ec2.DescribeSubnetsOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeSubnetsWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceVPC, region, fmt.Errorf("failed to describe subnets: %w", err))
+			return
+		}
+
+		subnets = append(subnets, out.Subnets...)
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	for _, s := range subnets {
+		cidrs := []string{aws.StringValue(s.CidrBlock)}
+		for _, a := range s.Ipv6CidrBlockAssociationSet {
+			cidrs = append(cidrs, aws.StringValue(a.Ipv6CidrBlock))
+		}
+
+		d.AddRow(ServiceVPC, region, inventory.Row{
+			UniqueAssetIdentifier: aws.StringValue(s.SubnetId),
+			IPv4orIPv6Address:     strings.Join(cidrs, "\n"),
+			Virtual:               true,
+			Public:                aws.BoolValue(s.MapPublicIpOnLaunch),
+			Location:              region,
+			AssetType:             AssetTypeVPCSubnet,
+			Function:              ec2Name(s.Tags),
+			Comments:              "Availability zone: " + aws.StringValue(s.AvailabilityZone),
+			SerialAssetTagNumber:  aws.StringValue(s.SubnetArn),
+			VLANNetworkID:         aws.StringValue(s.VpcId),
+			Tags:                  ec2Tags(s.Tags),
+		})
+	}
+}
+
+func (d *AWSData) loadNATGateways(ctx context.Context, ec2Svc ec2iface.EC2API, region, partition, accountID string) {
+	var gateways []*ec2.NatGateway
+	done := false
+	params := &ec2.DescribeNatGatewaysInput{}
+	for !done {
+		var out *ec2.DescribeNatGatewaysOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeNatGatewaysWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceVPC, region, fmt.Errorf("failed to describe nat gateways: %w", err))
+			return
+		}
+
+		gateways = append(gateways, out.NatGateways...)
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	for _, g := range gateways {
+		if aws.StringValue(g.State) == vpcStateDeleted {
+			continue
+		}
+
+		var public bool
+		var ips []string
+		var allocations []string
+		for _, a := range g.NatGatewayAddresses {
+			if aws.StringValue(a.PublicIp) != "" {
+				ips = append(ips, aws.StringValue(a.PublicIp))
+				public = true
+			}
+			if aws.StringValue(a.PrivateIp) != "" {
+				ips = append(ips, aws.StringValue(a.PrivateIp))
+			}
+			if aws.StringValue(a.AllocationId) != "" {
+				allocations = append(allocations, aws.StringValue(a.AllocationId))
+			}
+		}
+
+		comments := []string{"Subnet: " + aws.StringValue(g.SubnetId)}
+		if len(allocations) > 0 {
+			comments = append(comments, "Elastic IPs: "+strings.Join(allocations, ", "))
+		}
+
+		d.AddRow(ServiceVPC, region, inventory.Row{
+			UniqueAssetIdentifier: aws.StringValue(g.NatGatewayId),
+			IPv4orIPv6Address:     strings.Join(ips, "\n"),
+			Virtual:               true,
+			Public:                public,
+			Location:              region,
+			AssetType:             AssetTypeNATGateway,
+			Function:              ec2Name(g.Tags),
+			Comments:              strings.Join(comments, "\n"),
+			SerialAssetTagNumber:  ec2ARN(partition, region, accountID, "natgateway", aws.StringValue(g.NatGatewayId)),
+			VLANNetworkID:         aws.StringValue(g.VpcId),
+			Tags:                  ec2Tags(g.Tags),
+		})
+	}
+}
+
+func (d *AWSData) loadInternetGateways(ctx context.Context, ec2Svc ec2iface.EC2API, region, partition string) {
+	var gateways []*ec2.InternetGateway
+	done := false
+	params := &ec2.DescribeInternetGatewaysInput{}
+	for !done {
+		var out *ec2.DescribeInternetGatewaysOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeInternetGatewaysWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceVPC, region, fmt.Errorf("failed to describe internet gateways: %w", err))
+			return
+		}
+
+		gateways = append(gateways, out.InternetGateways...)
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	for _, g := range gateways {
+		d.AddRow(ServiceVPC, region, inventory.Row{
+			UniqueAssetIdentifier: aws.StringValue(g.InternetGatewayId),
+			Virtual:               true,
+			Public:                true,
+			Location:              region,
+			AssetType:             AssetTypeInternetGateway,
+			Function:              ec2Name(g.Tags),
+			SerialAssetTagNumber:  ec2ARN(partition, region, aws.StringValue(g.OwnerId), "internet-gateway", aws.StringValue(g.InternetGatewayId)),
+			VLANNetworkID:         internetGatewayVPC(g.Attachments),
+			Tags:                  ec2Tags(g.Tags),
+		})
+	}
+}
+
+func (d *AWSData) loadEgressOnlyInternetGateways(ctx context.Context, ec2Svc ec2iface.EC2API, region, partition, accountID string) {
+	var gateways []*ec2.EgressOnlyInternetGateway
+	done := false
+	params := &ec2.DescribeEgressOnlyInternetGatewaysInput{}
+	for !done {
+		var out *ec2.DescribeEgressOnlyInternetGatewaysOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeEgressOnlyInternetGatewaysWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceVPC, region, fmt.Errorf("failed to describe egress-only internet gateways: %w", err))
+			return
+		}
+
+		gateways = append(gateways, out.EgressOnlyInternetGateways...)
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	for _, g := range gateways {
+		d.AddRow(ServiceVPC, region, inventory.Row{
+			UniqueAssetIdentifier: aws.StringValue(g.EgressOnlyInternetGatewayId),
+			Virtual:               true,
+			Location:              region,
+			AssetType:             AssetTypeEgressOnlyInternetGateway,
+			Function:              ec2Name(g.Tags),
+			SerialAssetTagNumber:  ec2ARN(partition, region, accountID, "egress-only-internet-gateway", aws.StringValue(g.EgressOnlyInternetGatewayId)),
+			VLANNetworkID:         internetGatewayVPC(g.Attachments),
+			Tags:                  ec2Tags(g.Tags),
+		})
+	}
+}
+
+func (d *AWSData) loadVPCEndpoints(ctx context.Context, ec2Svc ec2iface.EC2API, region, partition string) {
+	var vpcEndpoints []*ec2.VpcEndpoint
+	done := false
+	params := &ec2.DescribeVpcEndpointsInput{}
+	for !done {
+		var out *ec2.DescribeVpcEndpointsOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeVpcEndpointsWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceVPC, region, fmt.Errorf("failed to describe vpc endpoints: %w", err))
+			return
+		}
+
+		vpcEndpoints = append(vpcEndpoints, out.VpcEndpoints...)
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	for _, e := range vpcEndpoints {
+		if strings.ToLower(aws.StringValue(e.State)) == vpcStateDeleted {
+			continue
+		}
+
+		var dnsNames []string
+		for _, entry := range e.DnsEntries {
+			dnsNames = append(dnsNames, aws.StringValue(entry.DnsName))
+		}
+
+		d.AddRow(ServiceVPC, region, inventory.Row{
+			UniqueAssetIdentifier: aws.StringValue(e.VpcEndpointId),
+			Virtual:               true,
+			DNSNameOrURL:          strings.Join(dnsNames, "\n"),
+			Location:              region,
+			AssetType:             AssetTypeVPCEndpoint,
+			Function:              aws.StringValue(e.ServiceName),
+			Comments:              aws.StringValue(e.VpcEndpointType),
+			SerialAssetTagNumber:  ec2ARN(partition, region, aws.StringValue(e.OwnerId), "vpc-endpoint", aws.StringValue(e.VpcEndpointId)),
+			VLANNetworkID:         aws.StringValue(e.VpcId),
+			Tags:                  ec2Tags(e.Tags),
+		})
+	}
+}
+
+func (d *AWSData) loadTransitGatewayAttachments(ctx context.Context, ec2Svc ec2iface.EC2API, region, partition string) {
+	var attachments []*ec2.TransitGatewayAttachment
+	done := false
+	params := &ec2.DescribeTransitGatewayAttachmentsInput{}
+	for !done {
+		var out *ec2.DescribeTransitGatewayAttachmentsOutput
+		err := d.retry(ctx, ServiceEC2, func() (err error) {
+			out, err = ec2Svc.DescribeTransitGatewayAttachmentsWithContext(ctx, params)
+			return
+		})
+		if err != nil {
+			d.AddError(ServiceVPC, region, fmt.Errorf("failed to describe transit gateway attachments: %w", err))
+			return
+		}
+
+		attachments = append(attachments, out.TransitGatewayAttachments...)
+
+		if out.NextToken == nil {
+			done = true
+		} else {
+			params.NextToken = out.NextToken
+		}
+	}
+
+	for _, a := range attachments {
+		if aws.StringValue(a.State) == vpcStateDeleted {
+			continue
+		}
+
+		var vpcID string
+		if aws.StringValue(a.ResourceType) == ec2.TransitGatewayAttachmentResourceTypeVpc {
+			vpcID = aws.StringValue(a.ResourceId)
+		}
+
+		d.AddRow(ServiceVPC, region, inventory.Row{
+			UniqueAssetIdentifier: aws.StringValue(a.TransitGatewayAttachmentId),
+			Virtual:               true,
+			Location:              region,
+			AssetType:             AssetTypeTransitGatewayAttachment,
+			Function:              ec2Name(a.Tags),
+			Comments:              fmt.Sprintf("Transit gateway: %s\nAttached to: %s %s", aws.StringValue(a.TransitGatewayId), aws.StringValue(a.ResourceType), aws.StringValue(a.ResourceId)),
+			SerialAssetTagNumber:  ec2ARN(partition, region, aws.StringValue(a.TransitGatewayOwnerId), "transit-gateway-attachment", aws.StringValue(a.TransitGatewayAttachmentId)),
+			VLANNetworkID:         vpcID,
+			Tags:                  ec2Tags(a.Tags),
+		})
+	}
+}
+
+// internetGatewayVPC returns the ID of the VPC an internet gateway is attached to, if any
+func internetGatewayVPC(attachments []*ec2.InternetGatewayAttachment) string {
+	if len(attachments) == 0 {
+		return ""
+	}
+
+	return aws.StringValue(attachments[0].VpcId)
+}
+
+// ec2ARN returns the ARN of an EC2 resource, or an empty string when the account owning it is not known
+func ec2ARN(partition, region, accountID, resourceType, id string) string {
+	if accountID == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("arn:%s:ec2:%s:%s:%s/%s", partition, region, accountID, resourceType, id)
+}
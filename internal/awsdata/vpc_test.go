@@ -0,0 +1,369 @@
+package awsdata_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/manywho/awsinventory/internal/awsdata"
+	"github.com/manywho/awsinventory/internal/inventory"
+)
+
+var testVPCRows = []inventory.Row{
+	{
+		UniqueAssetIdentifier: "eigw-12345678",
+		Virtual:               true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeEgressOnlyInternetGateway,
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:012345678910:egress-only-internet-gateway/eigw-12345678",
+		VLANNetworkID:         "vpc-12345678",
+	},
+	{
+		UniqueAssetIdentifier: "igw-12345678",
+		Virtual:               true,
+		Public:                true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeInternetGateway,
+		Function:              "test internet gateway",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:internet-gateway/igw-12345678",
+		VLANNetworkID:         "vpc-12345678",
+		Tags:                  map[string]string{"Name": "test internet gateway"},
+	},
+	{
+		UniqueAssetIdentifier: "nat-0123456789abcdef0",
+		IPv4orIPv6Address:     "203.0.113.10\n10.0.1.25",
+		Virtual:               true,
+		Public:                true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeNATGateway,
+		Comments:              "Subnet: subnet-12345678\nElastic IPs: eipalloc-12345678",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:012345678910:natgateway/nat-0123456789abcdef0",
+		VLANNetworkID:         "vpc-12345678",
+	},
+	{
+		UniqueAssetIdentifier: "subnet-12345678",
+		IPv4orIPv6Address:     "10.0.1.0/24\n2600:1f18:abcd:1201::/64",
+		Virtual:               true,
+		Public:                true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeVPCSubnet,
+		Function:              "test public subnet",
+		Comments:              "Availability zone: us-east-1a",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:subnet/subnet-12345678",
+		VLANNetworkID:         "vpc-12345678",
+		Tags:                  map[string]string{"Name": "test public subnet"},
+	},
+	{
+		UniqueAssetIdentifier: "tgw-attach-12345678",
+		Virtual:               true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeTransitGatewayAttachment,
+		Comments:              "Transit gateway: tgw-12345678\nAttached to: vpc vpc-12345678",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:transit-gateway-attachment/tgw-attach-12345678",
+		VLANNetworkID:         "vpc-12345678",
+	},
+	{
+		UniqueAssetIdentifier: "vpc-12345678",
+		IPv4orIPv6Address:     "10.0.0.0/16\n10.1.0.0/16\n2600:1f18:abcd:1200::/56",
+		Virtual:               true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeVPC,
+		Function:              "test vpc",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-12345678",
+		VLANNetworkID:         "vpc-12345678",
+		Tags:                  map[string]string{"Name": "test vpc", "Owner": "test owner"},
+	},
+	{
+		UniqueAssetIdentifier: "vpc-abcdefgh",
+		IPv4orIPv6Address:     "172.31.0.0/16",
+		Virtual:               true,
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeVPC,
+		Comments:              "Default VPC",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-abcdefgh",
+		VLANNetworkID:         "vpc-abcdefgh",
+	},
+	{
+		UniqueAssetIdentifier: "vpce-12345678",
+		Virtual:               true,
+		DNSNameOrURL:          "vpce-12345678-abcdefgh.sqs.us-east-1.vpce.amazonaws.com\nsqs.us-east-1.amazonaws.com",
+		Location:              DefaultRegion,
+		AssetType:             AssetTypeVPCEndpoint,
+		Function:              "com.amazonaws.us-east-1.sqs",
+		Comments:              "Interface",
+		SerialAssetTagNumber:  "arn:aws:ec2:us-east-1:123456789012:vpc-endpoint/vpce-12345678",
+		VLANNetworkID:         "vpc-12345678",
+	},
+}
+
+// Test Data
+var testEC2DescribeVpcsOutputPage1 = &ec2.DescribeVpcsOutput{
+	NextToken: aws.String("vpc-abcdefgh"),
+	Vpcs: []*ec2.Vpc{
+		{
+			VpcId:   aws.String("vpc-12345678"),
+			OwnerId: aws.String("123456789012"),
+			CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+				{
+					CidrBlock: aws.String("10.0.0.0/16"),
+				},
+				{
+					CidrBlock: aws.String("10.1.0.0/16"),
+				},
+			},
+			Ipv6CidrBlockAssociationSet: []*ec2.VpcIpv6CidrBlockAssociation{
+				{
+					Ipv6CidrBlock: aws.String("2600:1f18:abcd:1200::/56"),
+				},
+			},
+			IsDefault: aws.Bool(false),
+			Tags: []*ec2.Tag{
+				{
+					Key:   aws.String("Name"),
+					Value: aws.String("test vpc"),
+				},
+				{
+					Key:   aws.String("Owner"),
+					Value: aws.String("test owner"),
+				},
+			},
+		},
+	},
+}
+
+var testEC2DescribeVpcsOutputPage2 = &ec2.DescribeVpcsOutput{
+	Vpcs: []*ec2.Vpc{
+		{
+			VpcId:   aws.String("vpc-abcdefgh"),
+			OwnerId: aws.String("123456789012"),
+			CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+				{
+					CidrBlock: aws.String("172.31.0.0/16"),
+				},
+			},
+			IsDefault: aws.Bool(true),
+		},
+	},
+}
+
+var testEC2DescribeSubnetsOutput = &ec2.DescribeSubnetsOutput{
+	Subnets: []*ec2.Subnet{
+		{
+			SubnetId:         aws.String("subnet-12345678"),
+			SubnetArn:        aws.String(testVPCRows[3].SerialAssetTagNumber),
+			VpcId:            aws.String("vpc-12345678"),
+			CidrBlock:        aws.String("10.0.1.0/24"),
+			AvailabilityZone: aws.String("us-east-1a"),
+			Ipv6CidrBlockAssociationSet: []*ec2.SubnetIpv6CidrBlockAssociation{
+				{
+					Ipv6CidrBlock: aws.String("2600:1f18:abcd:1201::/64"),
+				},
+			},
+			MapPublicIpOnLaunch: aws.Bool(true),
+			Tags: []*ec2.Tag{
+				{
+					Key:   aws.String("Name"),
+					Value: aws.String("test public subnet"),
+				},
+			},
+		},
+	},
+}
+
+var testEC2DescribeNatGatewaysOutput = &ec2.DescribeNatGatewaysOutput{
+	NatGateways: []*ec2.NatGateway{
+		{
+			NatGatewayId: aws.String("nat-0123456789abcdef0"),
+			State:        aws.String(ec2.NatGatewayStateAvailable),
+			SubnetId:     aws.String("subnet-12345678"),
+			VpcId:        aws.String("vpc-12345678"),
+			NatGatewayAddresses: []*ec2.NatGatewayAddress{
+				{
+					AllocationId:       aws.String("eipalloc-12345678"),
+					NetworkInterfaceId: aws.String("eni-12345678"),
+					PrivateIp:          aws.String("10.0.1.25"),
+					PublicIp:           aws.String("203.0.113.10"),
+				},
+			},
+		},
+		{
+			NatGatewayId: aws.String("nat-0fedcba9876543210"),
+			State:        aws.String(ec2.NatGatewayStateDeleted),
+			SubnetId:     aws.String("subnet-12345678"),
+			VpcId:        aws.String("vpc-12345678"),
+		},
+	},
+}
+
+var testEC2DescribeInternetGatewaysOutput = &ec2.DescribeInternetGatewaysOutput{
+	InternetGateways: []*ec2.InternetGateway{
+		{
+			InternetGatewayId: aws.String("igw-12345678"),
+			OwnerId:           aws.String("123456789012"),
+			Attachments: []*ec2.InternetGatewayAttachment{
+				{
+					State: aws.String("available"),
+					VpcId: aws.String("vpc-12345678"),
+				},
+			},
+			Tags: []*ec2.Tag{
+				{
+					Key:   aws.String("Name"),
+					Value: aws.String("test internet gateway"),
+				},
+			},
+		},
+	},
+}
+
+var testEC2DescribeEgressOnlyInternetGatewaysOutput = &ec2.DescribeEgressOnlyInternetGatewaysOutput{
+	EgressOnlyInternetGateways: []*ec2.EgressOnlyInternetGateway{
+		{
+			EgressOnlyInternetGatewayId: aws.String("eigw-12345678"),
+			Attachments: []*ec2.InternetGatewayAttachment{
+				{
+					State: aws.String("attached"),
+					VpcId: aws.String("vpc-12345678"),
+				},
+			},
+		},
+	},
+}
+
+var testEC2DescribeVpcEndpointsOutput = &ec2.DescribeVpcEndpointsOutput{
+	VpcEndpoints: []*ec2.VpcEndpoint{
+		{
+			VpcEndpointId:   aws.String("vpce-12345678"),
+			VpcEndpointType: aws.String(ec2.VpcEndpointTypeInterface),
+			ServiceName:     aws.String("com.amazonaws.us-east-1.sqs"),
+			State:           aws.String(ec2.StateAvailable),
+			OwnerId:         aws.String("123456789012"),
+			VpcId:           aws.String("vpc-12345678"),
+			DnsEntries: []*ec2.DnsEntry{
+				{
+					DnsName: aws.String("vpce-12345678-abcdefgh.sqs.us-east-1.vpce.amazonaws.com"),
+				},
+				{
+					DnsName: aws.String("sqs.us-east-1.amazonaws.com"),
+				},
+			},
+		},
+		{
+			VpcEndpointId:   aws.String("vpce-abcdefgh"),
+			VpcEndpointType: aws.String(ec2.VpcEndpointTypeGateway),
+			ServiceName:     aws.String("com.amazonaws.us-east-1.s3"),
+			State:           aws.String(ec2.StateDeleted),
+			OwnerId:         aws.String("123456789012"),
+			VpcId:           aws.String("vpc-12345678"),
+		},
+	},
+}
+
+var testEC2DescribeTransitGatewayAttachmentsOutput = &ec2.DescribeTransitGatewayAttachmentsOutput{
+	TransitGatewayAttachments: []*ec2.TransitGatewayAttachment{
+		{
+			TransitGatewayAttachmentId: aws.String("tgw-attach-12345678"),
+			TransitGatewayId:           aws.String("tgw-12345678"),
+			TransitGatewayOwnerId:      aws.String("123456789012"),
+			ResourceType:               aws.String(ec2.TransitGatewayAttachmentResourceTypeVpc),
+			ResourceId:                 aws.String("vpc-12345678"),
+			State:                      aws.String(ec2.TransitGatewayAttachmentStateAvailable),
+		},
+	},
+}
+
+// Mocks
+func (e EC2Mock) DescribeVpcsWithContext(ctx aws.Context, cfg *ec2.DescribeVpcsInput, opts ...request.Option) (*ec2.DescribeVpcsOutput, error) {
+	if cfg.NextToken == nil {
+		return testEC2DescribeVpcsOutputPage1, nil
+	}
+
+	return testEC2DescribeVpcsOutputPage2, nil
+}
+
+func (e EC2Mock) DescribeSubnetsWithContext(ctx aws.Context, cfg *ec2.DescribeSubnetsInput, opts ...request.Option) (*ec2.DescribeSubnetsOutput, error) {
+	return testEC2DescribeSubnetsOutput, nil
+}
+
+func (e EC2Mock) DescribeNatGatewaysWithContext(ctx aws.Context, cfg *ec2.DescribeNatGatewaysInput, opts ...request.Option) (*ec2.DescribeNatGatewaysOutput, error) {
+	return testEC2DescribeNatGatewaysOutput, nil
+}
+
+func (e EC2Mock) DescribeInternetGatewaysWithContext(ctx aws.Context, cfg *ec2.DescribeInternetGatewaysInput, opts ...request.Option) (*ec2.DescribeInternetGatewaysOutput, error) {
+	return testEC2DescribeInternetGatewaysOutput, nil
+}
+
+func (e EC2Mock) DescribeEgressOnlyInternetGatewaysWithContext(ctx aws.Context, cfg *ec2.DescribeEgressOnlyInternetGatewaysInput, opts ...request.Option) (*ec2.DescribeEgressOnlyInternetGatewaysOutput, error) {
+	return testEC2DescribeEgressOnlyInternetGatewaysOutput, nil
+}
+
+func (e EC2Mock) DescribeVpcEndpointsWithContext(ctx aws.Context, cfg *ec2.DescribeVpcEndpointsInput, opts ...request.Option) (*ec2.DescribeVpcEndpointsOutput, error) {
+	return testEC2DescribeVpcEndpointsOutput, nil
+}
+
+func (e EC2Mock) DescribeTransitGatewayAttachmentsWithContext(ctx aws.Context, cfg *ec2.DescribeTransitGatewayAttachmentsInput, opts ...request.Option) (*ec2.DescribeTransitGatewayAttachmentsOutput, error) {
+	return testEC2DescribeTransitGatewayAttachmentsOutput, nil
+}
+
+func (e EC2ErrorMock) DescribeVpcsWithContext(ctx aws.Context, cfg *ec2.DescribeVpcsInput, opts ...request.Option) (*ec2.DescribeVpcsOutput, error) {
+	return &ec2.DescribeVpcsOutput{}, testError
+}
+
+func (e EC2ErrorMock) DescribeSubnetsWithContext(ctx aws.Context, cfg *ec2.DescribeSubnetsInput, opts ...request.Option) (*ec2.DescribeSubnetsOutput, error) {
+	return &ec2.DescribeSubnetsOutput{}, testError
+}
+
+func (e EC2ErrorMock) DescribeNatGatewaysWithContext(ctx aws.Context, cfg *ec2.DescribeNatGatewaysInput, opts ...request.Option) (*ec2.DescribeNatGatewaysOutput, error) {
+	return &ec2.DescribeNatGatewaysOutput{}, testError
+}
+
+func (e EC2ErrorMock) DescribeInternetGatewaysWithContext(ctx aws.Context, cfg *ec2.DescribeInternetGatewaysInput, opts ...request.Option) (*ec2.DescribeInternetGatewaysOutput, error) {
+	return &ec2.DescribeInternetGatewaysOutput{}, testError
+}
+
+func (e EC2ErrorMock) DescribeEgressOnlyInternetGatewaysWithContext(ctx aws.Context, cfg *ec2.DescribeEgressOnlyInternetGatewaysInput, opts ...request.Option) (*ec2.DescribeEgressOnlyInternetGatewaysOutput, error) {
+	return &ec2.DescribeEgressOnlyInternetGatewaysOutput{}, testError
+}
+
+func (e EC2ErrorMock) DescribeVpcEndpointsWithContext(ctx aws.Context, cfg *ec2.DescribeVpcEndpointsInput, opts ...request.Option) (*ec2.DescribeVpcEndpointsOutput, error) {
+	return &ec2.DescribeVpcEndpointsOutput{}, testError
+}
+
+func (e EC2ErrorMock) DescribeTransitGatewayAttachmentsWithContext(ctx aws.Context, cfg *ec2.DescribeTransitGatewayAttachmentsInput, opts ...request.Option) (*ec2.DescribeTransitGatewayAttachmentsOutput, error) {
+	return &ec2.DescribeTransitGatewayAttachmentsOutput{}, testError
+}
+
+// Tests
+func TestCanLoadVPCNetworking(t *testing.T) {
+	d := New(logrus.New(), TestClients{EC2: EC2Mock{}})
+
+	var rows []inventory.Row
+	d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceVPC}, func(row inventory.Row) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].UniqueAssetIdentifier < rows[j].UniqueAssetIdentifier
+	})
+
+	require.Equal(t, testVPCRows, rows)
+}
+
+func TestLoadVPCNetworkingLogsError(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+
+	d := New(logger, TestClients{EC2: EC2ErrorMock{}})
+
+	report := d.Load(context.Background(), []string{DefaultRegion}, []string{ServiceVPC}, nil)
+
+	require.Len(t, report.Errors, 8)
+	assertTestErrorWasLogged(t, hook.Entries)
+}
@@ -5,7 +5,8 @@ import (
 	"io"
 )
 
-var csvHeaders = []string{
+// templateHeaders are the headings of the columns in the FedRAMP template
+var templateHeaders = []string{
 	"Unique Asset Identifier",
 	"IPv4 or IPv6 Address",
 	"Virtual",
@@ -31,15 +32,35 @@ var csvHeaders = []string{
 	"ApplicationAdministrator/Owner",
 }
 
+// accountHeaders are the headings of the columns added by WithAccountColumns, after those of the template
+var accountHeaders = []string{
+	"Account ID",
+	"Account Alias",
+}
+
+// csvHeaders are the headings of every field of a row, in the order of Row.StringSlice
+var csvHeaders = append(append([]string{}, templateHeaders...), accountHeaders...)
+
+// FormatCSV is the name of the csv format
+const FormatCSV = "csv"
+
+func init() {
+	RegisterFormat(FormatCSV, func(w io.Writer, opts ...WriterOption) (Writer, error) {
+		return NewCSV(w, opts...)
+	})
+}
+
 // CSV handles a csv format inventory
 type CSV struct {
-	writer *csv.Writer
+	writer  *csv.Writer
+	options writerOptions
 }
 
 // NewCSV returns a new csv object ready to have rows written to it
-func NewCSV(writer io.Writer) (c CSV, err error) {
+func NewCSV(writer io.Writer, opts ...WriterOption) (c CSV, err error) {
 	c = CSV{
-		writer: csv.NewWriter(writer),
+		writer:  csv.NewWriter(writer),
+		options: newWriterOptions(opts),
 	}
 
 	err = c.writeHeaders()
@@ -49,15 +70,22 @@ func NewCSV(writer io.Writer) (c CSV, err error) {
 
 // writeHeaders writes the column headings to a csv writer
 func (c CSV) writeHeaders() error {
-	return c.writer.Write(csvHeaders)
+	return c.writer.Write(c.options.headers())
 }
 
 // WriteRow writes the row to the csv writer
 func (c CSV) WriteRow(r Row) error {
-	return c.writer.Write(r.StringSlice())
+	return c.writer.Write(c.options.record(r))
 }
 
 // Flush flushes the buffer to the writer
 func (c CSV) Flush() {
 	c.writer.Flush()
 }
+
+// Close flushes the buffer to the writer, returning any error encountered while writing.
+// It does not close the underlying writer.
+func (c CSV) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
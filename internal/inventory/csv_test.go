@@ -19,7 +19,7 @@ func TestNewCSVHasHeaders(t *testing.T) {
 	}
 	c.Flush()
 
-	expected := strings.Join(csvHeaders, ",")
+	expected := strings.Join(templateHeaders, ",")
 	actual := strings.TrimSpace(buf.String())
 
 	require.Equal(t, expected, actual, "wrote unexpected csv headers")
@@ -37,8 +37,37 @@ func TestNewCSVWritesRow(t *testing.T) {
 	require.NoError(t, c.WriteRow(testRow))
 	c.Flush()
 
-	expected := strings.Join(testRow.StringSlice(), ",")
+	expected := strings.Join(testRow.StringSlice()[:len(templateHeaders)], ",")
 	actual := strings.TrimSpace(buf.String())
 
 	require.Contains(t, actual, expected, "failed to find row")
 }
+
+func TestNewCSVWritesAccountColumns(t *testing.T) {
+	var buf bytes.Buffer
+
+	c, err := NewCSV(&buf, WithAccountColumns())
+	require.NoError(t, err)
+	require.NoError(t, c.WriteRow(testRow))
+	require.NoError(t, c.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, strings.Join(csvHeaders, ","), lines[0])
+	require.Equal(t, strings.Join(testRow.StringSlice(), ","), lines[1])
+}
+
+func TestNewCSVWritesTagColumns(t *testing.T) {
+	var buf bytes.Buffer
+
+	c, err := NewCSV(&buf, WithTagColumns("Owner", "CostCenter"))
+	require.NoError(t, err)
+
+	row := testRow
+	row.Tags = map[string]string{"Owner": "test owner", "Other": "ignored"}
+	require.NoError(t, c.WriteRow(row))
+	require.NoError(t, c.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.True(t, strings.HasSuffix(lines[0], ",Tag: Owner,Tag: CostCenter"), "expected tag column headings")
+	require.True(t, strings.HasSuffix(lines[1], ",test owner,"), "expected tag column values")
+}
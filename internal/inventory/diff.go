@@ -0,0 +1,223 @@
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Change is a field with a different value in two versions of a row
+type Change struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Modification is a row found in both inventories with different values in some of its fields
+type Modification struct {
+	// Row is the row from the new inventory
+	Row     Row
+	Changes []Change
+}
+
+// Diff holds the differences between two inventories
+type Diff struct {
+	Added    []Row
+	Removed  []Row
+	Modified []Modification
+}
+
+// rowKey identifies the asset in a row, so it can be found in another inventory
+func rowKey(r Row) string {
+	return r.UniqueAssetIdentifier + "\x00" + r.SerialAssetTagNumber
+}
+
+// Compare returns the rows added, removed and modified in the new inventory compared to the old inventory.
+// Rows are matched by their unique asset identifier and serial number, and listed in the order of the inventory
+// they were found in. Rows sharing the same identifier and serial number are matched in the order they appear in
+// each inventory, so none of them are lost.
+func Compare(oldRows, newRows []Row) *Diff {
+	d := &Diff{}
+
+	oldByKey := make(map[string][]Row)
+	for _, r := range oldRows {
+		oldByKey[rowKey(r)] = append(oldByKey[rowKey(r)], r)
+	}
+
+	newCount := make(map[string]int)
+	for _, r := range newRows {
+		newCount[rowKey(r)]++
+	}
+
+	oldSeen := make(map[string]int)
+	for _, r := range oldRows {
+		oldSeen[rowKey(r)]++
+		if oldSeen[rowKey(r)] > newCount[rowKey(r)] {
+			d.Removed = append(d.Removed, r)
+		}
+	}
+
+	newSeen := make(map[string]int)
+	for _, r := range newRows {
+		n := newSeen[rowKey(r)]
+		newSeen[rowKey(r)]++
+		if n >= len(oldByKey[rowKey(r)]) {
+			d.Added = append(d.Added, r)
+			continue
+		}
+
+		if changes := compareRows(oldByKey[rowKey(r)][n], r); len(changes) > 0 {
+			d.Modified = append(d.Modified, Modification{
+				Row:     r,
+				Changes: changes,
+			})
+		}
+	}
+
+	return d
+}
+
+// compareRows returns the fields with different values in the two rows, named by their column headings
+func compareRows(oldRow, newRow Row) (changes []Change) {
+	oldValues := oldRow.StringSlice()
+	newValues := newRow.StringSlice()
+
+	for i := range csvHeaders {
+		if oldValues[i] != newValues[i] {
+			changes = append(changes, Change{
+				Field: csvHeaders[i],
+				Old:   oldValues[i],
+				New:   newValues[i],
+			})
+		}
+	}
+
+	return
+}
+
+// Empty returns true when the inventories have the same rows
+func (d *Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// WriteText writes the differences in a form for people to read
+func (d *Diff) WriteText(w io.Writer) error {
+	for _, r := range d.Added {
+		if _, err := fmt.Fprintf(w, "+ %s\n", describeRow(r)); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range d.Removed {
+		if _, err := fmt.Fprintf(w, "- %s\n", describeRow(r)); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range d.Modified {
+		if _, err := fmt.Fprintf(w, "~ %s\n", describeRow(m.Row)); err != nil {
+			return err
+		}
+
+		for _, c := range m.Changes {
+			if _, err := fmt.Fprintf(w, "    %s: %q -> %q\n", c.Field, c.Old, c.New); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d added, %d removed, %d modified\n", len(d.Added), len(d.Removed), len(d.Modified))
+
+	return err
+}
+
+// describeRow returns a single line identifying the asset in the row
+func describeRow(r Row) string {
+	if r.SerialAssetTagNumber == "" {
+		return fmt.Sprintf("%s: %s", r.AssetType, r.UniqueAssetIdentifier)
+	}
+
+	return fmt.Sprintf("%s: %s (%s)", r.AssetType, r.UniqueAssetIdentifier, r.SerialAssetTagNumber)
+}
+
+var diffCSVHeaders = []string{
+	"Change",
+	"Unique Asset Identifier",
+	"Serial #/Asset Tag #",
+	"Asset Type",
+	"Field",
+	"Old Value",
+	"New Value",
+}
+
+// WriteCSV writes the differences as a csv, with a line for each added or removed row and each modified field
+func (d *Diff) WriteCSV(w io.Writer) error {
+	c := csv.NewWriter(w)
+
+	if err := c.Write(diffCSVHeaders); err != nil {
+		return err
+	}
+
+	for _, r := range d.Added {
+		if err := c.Write([]string{"added", r.UniqueAssetIdentifier, r.SerialAssetTagNumber, r.AssetType, "", "", ""}); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range d.Removed {
+		if err := c.Write([]string{"removed", r.UniqueAssetIdentifier, r.SerialAssetTagNumber, r.AssetType, "", "", ""}); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range d.Modified {
+		for _, ch := range m.Changes {
+			if err := c.Write([]string{"modified", m.Row.UniqueAssetIdentifier, m.Row.SerialAssetTagNumber, m.Row.AssetType, ch.Field, ch.Old, ch.New}); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.Flush()
+
+	return c.Error()
+}
+
+// WriteJSON writes the differences as json, with rows in the same form as the json format
+func (d *Diff) WriteJSON(w io.Writer) error {
+	type modification struct {
+		jsonRow
+		Changes []Change `json:"changes"`
+	}
+
+	out := struct {
+		Added    []jsonRow      `json:"added"`
+		Removed  []jsonRow      `json:"removed"`
+		Modified []modification `json:"modified"`
+	}{
+		Added:    []jsonRow{},
+		Removed:  []jsonRow{},
+		Modified: []modification{},
+	}
+
+	for _, r := range d.Added {
+		out.Added = append(out.Added, newJSONRow(r))
+	}
+
+	for _, r := range d.Removed {
+		out.Removed = append(out.Removed, newJSONRow(r))
+	}
+
+	for _, m := range d.Modified {
+		out.Modified = append(out.Modified, modification{
+			jsonRow: newJSONRow(m.Row),
+			Changes: m.Changes,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(out)
+}
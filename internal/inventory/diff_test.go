@@ -0,0 +1,137 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testDiffUnchangedRow = Row{UniqueAssetIdentifier: "unchanged", SerialAssetTagNumber: "arn:unchanged", AssetType: "EC2 Instance"}
+	testDiffRemovedRow   = Row{UniqueAssetIdentifier: "removed", SerialAssetTagNumber: "arn:removed", AssetType: "EC2 Instance"}
+	testDiffAddedRow     = Row{UniqueAssetIdentifier: "added", SerialAssetTagNumber: "arn:added", AssetType: "EC2 Instance"}
+	testDiffOldRow       = Row{UniqueAssetIdentifier: "modified", SerialAssetTagNumber: "arn:modified", AssetType: "EC2 Instance", IPv4orIPv6Address: "10.0.0.1"}
+	testDiffNewRow       = Row{UniqueAssetIdentifier: "modified", SerialAssetTagNumber: "arn:modified", AssetType: "EC2 Instance", IPv4orIPv6Address: "10.0.0.2", Public: true}
+)
+
+func newTestDiff() *Diff {
+	return Compare(
+		[]Row{testDiffUnchangedRow, testDiffRemovedRow, testDiffOldRow},
+		[]Row{testDiffUnchangedRow, testDiffNewRow, testDiffAddedRow},
+	)
+}
+
+func TestCompareReturnsAddedRemovedAndModifiedRows(t *testing.T) {
+	d := newTestDiff()
+
+	require.Equal(t, []Row{testDiffAddedRow}, d.Added)
+	require.Equal(t, []Row{testDiffRemovedRow}, d.Removed)
+	require.Equal(t, []Modification{
+		{
+			Row: testDiffNewRow,
+			Changes: []Change{
+				{Field: "IPv4 or IPv6 Address", Old: "10.0.0.1", New: "10.0.0.2"},
+				{Field: "Public", Old: "No", New: "Yes"},
+			},
+		},
+	}, d.Modified)
+	require.False(t, d.Empty())
+}
+
+func TestCompareMatchesRowsBySerialNumber(t *testing.T) {
+	row := testDiffUnchangedRow
+	row.SerialAssetTagNumber = "arn:other"
+
+	d := Compare([]Row{testDiffUnchangedRow}, []Row{row})
+
+	require.Equal(t, []Row{row}, d.Added)
+	require.Equal(t, []Row{testDiffUnchangedRow}, d.Removed)
+}
+
+func TestCompareMatchesRowsWithTheSameKeyInOrder(t *testing.T) {
+	first := testDiffOldRow
+	second := testDiffOldRow
+	second.IPv4orIPv6Address = "10.0.0.3"
+	third := testDiffOldRow
+	third.IPv4orIPv6Address = "10.0.0.4"
+
+	d := Compare([]Row{first, second}, []Row{first, third, testDiffNewRow})
+
+	require.Equal(t, []Row{testDiffNewRow}, d.Added)
+	require.Empty(t, d.Removed)
+	require.Equal(t, []Modification{
+		{
+			Row: third,
+			Changes: []Change{
+				{Field: "IPv4 or IPv6 Address", Old: "10.0.0.3", New: "10.0.0.4"},
+			},
+		},
+	}, d.Modified)
+
+	d = Compare([]Row{first, second}, []Row{first})
+
+	require.Empty(t, d.Added)
+	require.Equal(t, []Row{second}, d.Removed)
+	require.Empty(t, d.Modified)
+}
+
+func TestCompareReturnsEmptyDiffForSameRows(t *testing.T) {
+	require.True(t, Compare([]Row{testRow}, []Row{testRow}).Empty())
+}
+
+func TestDiffCanBeWrittenAsText(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, newTestDiff().WriteText(&buf))
+
+	require.Equal(t, `+ EC2 Instance: added (arn:added)
+- EC2 Instance: removed (arn:removed)
+~ EC2 Instance: modified (arn:modified)
+    IPv4 or IPv6 Address: "10.0.0.1" -> "10.0.0.2"
+    Public: "No" -> "Yes"
+1 added, 1 removed, 1 modified
+`, buf.String())
+}
+
+func TestDiffCanBeWrittenAsCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, newTestDiff().WriteCSV(&buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		diffCSVHeaders,
+		{"added", "added", "arn:added", "EC2 Instance", "", "", ""},
+		{"removed", "removed", "arn:removed", "EC2 Instance", "", "", ""},
+		{"modified", "modified", "arn:modified", "EC2 Instance", "IPv4 or IPv6 Address", "10.0.0.1", "10.0.0.2"},
+		{"modified", "modified", "arn:modified", "EC2 Instance", "Public", "No", "Yes"},
+	}, records)
+}
+
+func TestDiffCanBeWrittenAsJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, newTestDiff().WriteJSON(&buf))
+
+	var actual struct {
+		Added    []map[string]interface{} `json:"added"`
+		Removed  []map[string]interface{} `json:"removed"`
+		Modified []struct {
+			UniqueAssetIdentifier string   `json:"unique_asset_identifier"`
+			Changes               []Change `json:"changes"`
+		} `json:"modified"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+
+	require.Len(t, actual.Added, 1)
+	require.Equal(t, "added", actual.Added[0]["unique_asset_identifier"])
+	require.Len(t, actual.Removed, 1)
+	require.Equal(t, "removed", actual.Removed[0]["unique_asset_identifier"])
+	require.Len(t, actual.Modified, 1)
+	require.Equal(t, "modified", actual.Modified[0].UniqueAssetIdentifier)
+	require.Len(t, actual.Modified[0].Changes, 2)
+}
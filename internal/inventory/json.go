@@ -0,0 +1,177 @@
+package inventory
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+const (
+	// FormatJSON is the name of the json format, an array of rows
+	FormatJSON = "json"
+
+	// FormatJSONL is the name of the json lines format, one row per line
+	FormatJSONL = "jsonl"
+)
+
+func init() {
+	RegisterFormat(FormatJSON, func(w io.Writer, opts ...WriterOption) (Writer, error) {
+		return NewJSON(w, opts...)
+	})
+
+	RegisterFormat(FormatJSONL, func(w io.Writer, opts ...WriterOption) (Writer, error) {
+		return NewJSONL(w, opts...)
+	})
+}
+
+// jsonRow is the representation of a Row in the json formats, and in yaml.
+// Fields holding several values, one per line, are split into arrays. Tags are only written for the keys in the
+// tag columns.
+type jsonRow struct {
+	UniqueAssetIdentifier          string            `json:"unique_asset_identifier" yaml:"unique_asset_identifier"`
+	IPAddresses                    []string          `json:"ip_addresses" yaml:"ip_addresses"`
+	Virtual                        bool              `json:"virtual" yaml:"virtual"`
+	Public                         bool              `json:"public" yaml:"public"`
+	DNSNames                       []string          `json:"dns_names" yaml:"dns_names"`
+	NetBIOSName                    string            `json:"netbios_name" yaml:"netbios_name"`
+	MACAddresses                   []string          `json:"mac_addresses" yaml:"mac_addresses"`
+	AuthenticatedScan              bool              `json:"authenticated_scan" yaml:"authenticated_scan"`
+	BaselineConfigurationName      string            `json:"baseline_configuration_name" yaml:"baseline_configuration_name"`
+	OSNameAndVersion               string            `json:"os_name_and_version" yaml:"os_name_and_version"`
+	Location                       string            `json:"location" yaml:"location"`
+	AssetType                      string            `json:"asset_type" yaml:"asset_type"`
+	HardwareMakeModel              string            `json:"hardware_make_model" yaml:"hardware_make_model"`
+	InLatestScan                   bool              `json:"in_latest_scan" yaml:"in_latest_scan"`
+	SoftwareDatabaseVendor         string            `json:"software_database_vendor" yaml:"software_database_vendor"`
+	SoftwareDatabaseNameAndVersion string            `json:"software_database_name_and_version" yaml:"software_database_name_and_version"`
+	PatchLevel                     string            `json:"patch_level" yaml:"patch_level"`
+	Function                       string            `json:"function" yaml:"function"`
+	Comments                       string            `json:"comments" yaml:"comments"`
+	SerialAssetTagNumber           string            `json:"serial_asset_tag_number" yaml:"serial_asset_tag_number"`
+	VLANNetworkID                  string            `json:"vlan_network_id" yaml:"vlan_network_id"`
+	SystemAdministratorOwner       string            `json:"system_administrator_owner" yaml:"system_administrator_owner"`
+	ApplicationAdministratorOwner  string            `json:"application_administrator_owner" yaml:"application_administrator_owner"`
+	AccountID                      string            `json:"account_id" yaml:"account_id"`
+	AccountAlias                   string            `json:"account_alias" yaml:"account_alias"`
+	Tags                           map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+func newJSONRow(r Row) jsonRow {
+	return jsonRow{
+		UniqueAssetIdentifier:          r.UniqueAssetIdentifier,
+		IPAddresses:                    splitLines(r.IPv4orIPv6Address),
+		Virtual:                        r.Virtual,
+		Public:                         r.Public,
+		DNSNames:                       splitLines(r.DNSNameOrURL),
+		NetBIOSName:                    r.NetBIOSName,
+		MACAddresses:                   splitLines(r.MACAddress),
+		AuthenticatedScan:              r.AuthenticatedScan,
+		BaselineConfigurationName:      r.BaselineConfigurationName,
+		OSNameAndVersion:               r.OSNameAndVersion,
+		Location:                       r.Location,
+		AssetType:                      r.AssetType,
+		HardwareMakeModel:              r.HardwareMakeModel,
+		InLatestScan:                   r.InLatestScan,
+		SoftwareDatabaseVendor:         r.SoftwareDatabaseVendor,
+		SoftwareDatabaseNameAndVersion: r.SoftwareDatabaseNameAndVersion,
+		PatchLevel:                     r.PatchLevel,
+		Function:                       r.Function,
+		Comments:                       r.Comments,
+		SerialAssetTagNumber:           r.SerialAssetTagNumber,
+		VLANNetworkID:                  r.VLANNetworkID,
+		SystemAdministratorOwner:       r.SystemAdministratorOwner,
+		ApplicationAdministratorOwner:  r.ApplicationAdministratorOwner,
+		AccountID:                      r.AccountID,
+		AccountAlias:                   r.AccountAlias,
+	}
+}
+
+// splitLines returns the lines of s, or an empty slice when s is empty
+func splitLines(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+
+	return strings.Split(s, "\n")
+}
+
+// JSON handles a json format inventory, an array of rows.
+// Rows are streamed to the underlying writer as they are written.
+type JSON struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+	rows    int
+	options writerOptions
+}
+
+// NewJSON returns a new json object ready to have rows written to it
+func NewJSON(writer io.Writer, opts ...WriterOption) (*JSON, error) {
+	w := bufio.NewWriter(writer)
+
+	j := &JSON{
+		writer:  w,
+		encoder: json.NewEncoder(w),
+		options: newWriterOptions(opts),
+	}
+
+	_, err := w.WriteString("[\n")
+
+	return j, err
+}
+
+// WriteRow writes the row to the array
+func (j *JSON) WriteRow(r Row) error {
+	if j.rows > 0 {
+		if _, err := j.writer.WriteString(","); err != nil {
+			return err
+		}
+	}
+	j.rows++
+
+	row := newJSONRow(r)
+	row.Tags = j.options.tags(r)
+
+	return j.encoder.Encode(row)
+}
+
+// Close ends the array and flushes the buffer to the writer. It does not close the underlying writer.
+func (j *JSON) Close() error {
+	if _, err := j.writer.WriteString("]\n"); err != nil {
+		return err
+	}
+
+	return j.writer.Flush()
+}
+
+// JSONL handles a json lines format inventory, one row per line.
+// Rows are streamed to the underlying writer as they are written.
+type JSONL struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+	options writerOptions
+}
+
+// NewJSONL returns a new json lines object ready to have rows written to it
+func NewJSONL(writer io.Writer, opts ...WriterOption) (*JSONL, error) {
+	w := bufio.NewWriter(writer)
+
+	return &JSONL{
+		writer:  w,
+		encoder: json.NewEncoder(w),
+		options: newWriterOptions(opts),
+	}, nil
+}
+
+// WriteRow writes the row as a line
+func (j *JSONL) WriteRow(r Row) error {
+	row := newJSONRow(r)
+	row.Tags = j.options.tags(r)
+
+	return j.encoder.Encode(row)
+}
+
+// Close flushes the buffer to the writer. It does not close the underlying writer.
+func (j *JSONL) Close() error {
+	return j.writer.Flush()
+}
@@ -0,0 +1,110 @@
+package inventory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testJSONRow = map[string]interface{}{
+	"unique_asset_identifier":            "UniqueAssetIdentifier",
+	"ip_addresses":                       []interface{}{"10.0.0.1", "10.0.0.2"},
+	"virtual":                            true,
+	"public":                             true,
+	"dns_names":                          []interface{}{"DNSNameOrURL"},
+	"netbios_name":                       "NetBIOSName",
+	"mac_addresses":                      []interface{}{},
+	"authenticated_scan":                 false,
+	"baseline_configuration_name":        "BaselineConfigurationName",
+	"os_name_and_version":                "OSNameAndVersion",
+	"location":                           "Location",
+	"asset_type":                         "AssetType",
+	"hardware_make_model":                "HardwareMakeModel",
+	"in_latest_scan":                     true,
+	"software_database_vendor":           "SoftwareDatabaseVendor",
+	"software_database_name_and_version": "SoftwareDatabaseNameAndVersion",
+	"patch_level":                        "PatchLevel",
+	"function":                           "Function",
+	"comments":                           "Comments",
+	"serial_asset_tag_number":            "SerialAssetTagNumber",
+	"vlan_network_id":                    "VLANNetworkID",
+	"system_administrator_owner":         "SystemAdministratorOwner",
+	"application_administrator_owner":    "ApplicationAdministratorOwner",
+	"account_id":                         "AccountID",
+	"account_alias":                      "AccountAlias",
+}
+
+func newTestJSONRow() Row {
+	row := testRow
+	row.IPv4orIPv6Address = "10.0.0.1\n10.0.0.2"
+	row.MACAddress = ""
+
+	return row
+}
+
+func TestNewJSONWritesEmptyArray(t *testing.T) {
+	var buf bytes.Buffer
+
+	j, err := NewJSON(&buf)
+	require.NoError(t, err)
+	require.NoError(t, j.Close())
+
+	var actual []interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	require.Empty(t, actual)
+}
+
+func TestNewJSONWritesRows(t *testing.T) {
+	var buf bytes.Buffer
+
+	j, err := NewJSON(&buf)
+	require.NoError(t, err)
+	require.NoError(t, j.WriteRow(newTestJSONRow()))
+	require.NoError(t, j.WriteRow(newTestJSONRow()))
+	require.NoError(t, j.Close())
+
+	var actual []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	require.Equal(t, []map[string]interface{}{testJSONRow, testJSONRow}, actual)
+}
+
+func TestNewJSONLWritesRowPerLine(t *testing.T) {
+	var buf bytes.Buffer
+
+	j, err := NewJSONL(&buf)
+	require.NoError(t, err)
+	require.NoError(t, j.WriteRow(newTestJSONRow()))
+	require.NoError(t, j.WriteRow(newTestJSONRow()))
+	require.NoError(t, j.Close())
+
+	var lines int
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		lines++
+
+		var actual map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &actual))
+		require.Equal(t, testJSONRow, actual)
+	}
+
+	require.Equal(t, 2, lines)
+}
+
+func TestNewJSONWritesTagsObject(t *testing.T) {
+	var buf bytes.Buffer
+
+	row := newTestJSONRow()
+	row.Tags = map[string]string{"Owner": "test owner", "Other": "ignored"}
+
+	j, err := NewJSONL(&buf, WithTagColumns("Owner", "CostCenter"))
+	require.NoError(t, err)
+	require.NoError(t, j.WriteRow(row))
+	require.NoError(t, j.Close())
+
+	var actual map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	require.Equal(t, map[string]interface{}{"Owner": "test owner"}, actual["tags"])
+}
@@ -0,0 +1,110 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateAsset is returned by NewMerger when more than one row has the same unique asset identifier
+var ErrDuplicateAsset = errors.New("duplicate unique asset identifier")
+
+// MergeMode decides which value is kept when a collected row and a merged row both have a value for a field
+type MergeMode string
+
+const (
+	// MergeOverride keeps the values of the merged row, using the collected values only for fields it leaves empty
+	MergeOverride MergeMode = "override"
+
+	// MergeSupplement keeps the values of the collected row, using the merged values only for fields it leaves empty
+	MergeSupplement MergeMode = "supplement"
+)
+
+// Merger combines collected rows with rows maintained elsewhere, such as assets outside of AWS.
+// Rows are matched by their unique asset identifier.
+type Merger struct {
+	mode   MergeMode
+	rows   []Row
+	byID   map[string]int
+	merged map[int]bool
+}
+
+// NewMerger returns a new Merger for the given rows, returning an error when two rows have the same unique asset
+// identifier. Fields holding No, such as Public, count as empty, so merging can set them to Yes but not back to No.
+func NewMerger(rows []Row, mode MergeMode) (*Merger, error) {
+	if mode != MergeOverride && mode != MergeSupplement {
+		return nil, fmt.Errorf("unknown merge mode %s", mode)
+	}
+
+	m := &Merger{
+		mode:   mode,
+		rows:   rows,
+		byID:   make(map[string]int),
+		merged: make(map[int]bool),
+	}
+
+	for i, r := range rows {
+		if _, dup := m.byID[r.UniqueAssetIdentifier]; dup {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateAsset, r.UniqueAssetIdentifier)
+		}
+
+		m.byID[r.UniqueAssetIdentifier] = i
+	}
+
+	return m, nil
+}
+
+// Merge returns the collected row combined with the row with the same unique asset identifier, if there is one
+func (m *Merger) Merge(collected Row) (row Row, merged bool) {
+	i, ok := m.byID[collected.UniqueAssetIdentifier]
+	if !ok {
+		return collected, false
+	}
+
+	m.merged[i] = true
+
+	if m.mode == MergeOverride {
+		return mergeRows(m.rows[i], collected), true
+	}
+
+	return mergeRows(collected, m.rows[i]), true
+}
+
+// Remaining returns the rows which have not been merged with a collected row, in their original order
+func (m *Merger) Remaining() []Row {
+	var rows []Row
+	for i, r := range m.rows {
+		if !m.merged[i] {
+			rows = append(rows, r)
+		}
+	}
+
+	return rows
+}
+
+// mergeRows returns the preferred row with any empty fields and missing tags filled from the other row
+func mergeRows(preferred, other Row) Row {
+	preferredValues := preferred.StringSlice()
+	otherValues := other.StringSlice()
+
+	var row Row
+	for i, header := range csvHeaders {
+		value := preferredValues[i]
+		if value == "" || value == getBoolString(false) {
+			value = otherValues[i]
+		}
+
+		// The values come from StringSlice, so are always valid
+		_ = setField(&row, header, value)
+	}
+
+	for _, tags := range []map[string]string{other.Tags, preferred.Tags} {
+		for k, v := range tags {
+			if row.Tags == nil {
+				row.Tags = make(map[string]string)
+			}
+			row.Tags[k] = v
+		}
+	}
+
+	return row
+}
@@ -0,0 +1,87 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testMergeCollectedRow = Row{
+		UniqueAssetIdentifier: "test-asset",
+		IPv4orIPv6Address:     "10.0.0.1",
+		Virtual:               true,
+		AssetType:             "EC2 Instance",
+	}
+	testMergeManualRow = Row{
+		UniqueAssetIdentifier:    "test-asset",
+		IPv4orIPv6Address:        "10.0.0.2",
+		Public:                   true,
+		AssetType:                "Web Server",
+		SystemAdministratorOwner: "ops@example.com",
+	}
+	testMergeLaptopRow = Row{
+		UniqueAssetIdentifier: "test-laptop",
+		AssetType:             "Laptop",
+	}
+)
+
+func TestMergerSupplementsCollectedRow(t *testing.T) {
+	m, err := NewMerger([]Row{testMergeManualRow, testMergeLaptopRow}, MergeSupplement)
+	require.NoError(t, err)
+
+	row, merged := m.Merge(testMergeCollectedRow)
+
+	require.True(t, merged)
+	require.Equal(t, Row{
+		UniqueAssetIdentifier:    "test-asset",
+		IPv4orIPv6Address:        "10.0.0.1",
+		Virtual:                  true,
+		Public:                   true,
+		AssetType:                "EC2 Instance",
+		SystemAdministratorOwner: "ops@example.com",
+	}, row)
+	require.Equal(t, []Row{testMergeLaptopRow}, m.Remaining())
+}
+
+func TestMergerOverridesCollectedRow(t *testing.T) {
+	m, err := NewMerger([]Row{testMergeManualRow}, MergeOverride)
+	require.NoError(t, err)
+
+	row, merged := m.Merge(testMergeCollectedRow)
+
+	require.True(t, merged)
+	require.Equal(t, Row{
+		UniqueAssetIdentifier:    "test-asset",
+		IPv4orIPv6Address:        "10.0.0.2",
+		Virtual:                  true,
+		Public:                   true,
+		AssetType:                "Web Server",
+		SystemAdministratorOwner: "ops@example.com",
+	}, row)
+	require.Empty(t, m.Remaining())
+}
+
+func TestMergerLeavesUnmatchedRows(t *testing.T) {
+	m, err := NewMerger([]Row{testMergeLaptopRow}, MergeOverride)
+	require.NoError(t, err)
+
+	row, merged := m.Merge(testMergeCollectedRow)
+
+	require.False(t, merged)
+	require.Equal(t, testMergeCollectedRow, row)
+	require.Equal(t, []Row{testMergeLaptopRow}, m.Remaining())
+}
+
+func TestNewMergerReturnsErrDuplicateAsset(t *testing.T) {
+	_, err := NewMerger([]Row{testMergeLaptopRow, testMergeLaptopRow}, MergeOverride)
+
+	require.True(t, errors.Is(err, ErrDuplicateAsset))
+}
+
+func TestNewMergerReturnsErrorForUnknownMode(t *testing.T) {
+	_, err := NewMerger([]Row{}, MergeMode("replace"))
+
+	require.Error(t, err)
+}
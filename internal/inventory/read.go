@@ -0,0 +1,287 @@
+package inventory
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidHeaders is returned by ReadCSV when the column headings are missing, unknown or repeated
+var ErrInvalidHeaders = errors.New("invalid column headings")
+
+// ErrInvalidBool is returned by ReadCSV when a column holding Yes or No has another value
+var ErrInvalidBool = errors.New("expected Yes or No")
+
+// ReadError is an error in a row of an inventory being read
+type ReadError struct {
+	// Row is the number of the row, counting the column headings as row 1, as in a spreadsheet
+	Row    int
+	Column string
+	Err    error
+}
+
+func (e *ReadError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+	}
+
+	return fmt.Sprintf("row %d, column %q: %s", e.Row, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ReadError) Unwrap() error {
+	return e.Err
+}
+
+// ReadCSV returns the rows of a csv format inventory, such as one written by CSV.
+// The columns may be in any order and some may be left out, but each heading must be one written by CSV and the
+// Unique Asset Identifier column is required. Columns holding Yes or No may also be empty, meaning No.
+// Tag columns set the tags of each row which has a value in them.
+func ReadCSV(reader io.Reader) ([]Row, error) {
+	r := csv.NewReader(reader)
+
+	headers, err := r.Read()
+	if err == io.EOF {
+		return nil, &ReadError{Row: 1, Err: ErrInvalidHeaders}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateHeaders(headers); err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for n := 2; ; n++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var row Row
+		for i, value := range record {
+			if err := setField(&row, headers[i], value); err != nil {
+				return nil, &ReadError{Row: n, Column: headers[i], Err: err}
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// validateHeaders returns an error when a heading is not one written by CSV or is repeated,
+// or the Unique Asset Identifier heading is missing. A byte order mark before the first heading is removed.
+func validateHeaders(headers []string) error {
+	if len(headers) > 0 {
+		headers[0] = strings.TrimPrefix(headers[0], "\ufeff")
+	}
+
+	seen := make(map[string]bool)
+	for _, h := range headers {
+		if indexOf(csvHeaders, h) < 0 && !isTagHeader(h) {
+			return &ReadError{Row: 1, Column: h, Err: fmt.Errorf("%w: unknown heading", ErrInvalidHeaders)}
+		}
+
+		if seen[h] {
+			return &ReadError{Row: 1, Column: h, Err: fmt.Errorf("%w: repeated heading", ErrInvalidHeaders)}
+		}
+		seen[h] = true
+	}
+
+	if !seen[csvHeaders[0]] {
+		return &ReadError{Row: 1, Column: csvHeaders[0], Err: fmt.Errorf("%w: missing heading", ErrInvalidHeaders)}
+	}
+
+	return nil
+}
+
+// ReadJSON returns the rows of a json or json lines format inventory, such as one written by JSON or JSONL
+func ReadJSON(reader io.Reader) ([]Row, error) {
+	r := bufio.NewReader(reader)
+
+	// An array is a json inventory, anything else is read as json lines
+	array, err := startsWith(r, '[')
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(r)
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []Row
+	for decoder.More() {
+		var j jsonRow
+		if err := decoder.Decode(&j); err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, j.row())
+	}
+
+	return rows, nil
+}
+
+// ReadYAML returns the rows of a yaml inventory, a list of rows with the same fields as the json format
+func ReadYAML(reader io.Reader) ([]Row, error) {
+	var list []jsonRow
+	if err := yaml.NewDecoder(reader).Decode(&list); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	var rows []Row
+	for _, j := range list {
+		rows = append(rows, j.row())
+	}
+
+	return rows, nil
+}
+
+// startsWith returns true when the first character other than whitespace is c
+func startsWith(r *bufio.Reader, c byte) (bool, error) {
+	for {
+		b, err := r.ReadByte()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		if !strings.ContainsRune(" \t\r\n", rune(b)) {
+			return b == c, r.UnreadByte()
+		}
+	}
+}
+
+// row returns the Row represented by the json row, joining fields holding several values one per line
+func (j jsonRow) row() Row {
+	return Row{
+		UniqueAssetIdentifier:          j.UniqueAssetIdentifier,
+		IPv4orIPv6Address:              strings.Join(j.IPAddresses, "\n"),
+		Virtual:                        j.Virtual,
+		Public:                         j.Public,
+		DNSNameOrURL:                   strings.Join(j.DNSNames, "\n"),
+		NetBIOSName:                    j.NetBIOSName,
+		MACAddress:                     strings.Join(j.MACAddresses, "\n"),
+		AuthenticatedScan:              j.AuthenticatedScan,
+		BaselineConfigurationName:      j.BaselineConfigurationName,
+		OSNameAndVersion:               j.OSNameAndVersion,
+		Location:                       j.Location,
+		AssetType:                      j.AssetType,
+		HardwareMakeModel:              j.HardwareMakeModel,
+		InLatestScan:                   j.InLatestScan,
+		SoftwareDatabaseVendor:         j.SoftwareDatabaseVendor,
+		SoftwareDatabaseNameAndVersion: j.SoftwareDatabaseNameAndVersion,
+		PatchLevel:                     j.PatchLevel,
+		Function:                       j.Function,
+		Comments:                       j.Comments,
+		SerialAssetTagNumber:           j.SerialAssetTagNumber,
+		VLANNetworkID:                  j.VLANNetworkID,
+		SystemAdministratorOwner:       j.SystemAdministratorOwner,
+		ApplicationAdministratorOwner:  j.ApplicationAdministratorOwner,
+		AccountID:                      j.AccountID,
+		AccountAlias:                   j.AccountAlias,
+		Tags:                           j.Tags,
+	}
+}
+
+// isTagHeader returns true when the heading is that of a tag column
+func isTagHeader(header string) bool {
+	return strings.HasPrefix(header, tagHeaderPrefix) && len(header) > len(tagHeaderPrefix)
+}
+
+// setField sets the field of the row in the column with the given heading
+func setField(r *Row, header, value string) (err error) {
+	if isTagHeader(header) {
+		if value != "" {
+			if r.Tags == nil {
+				r.Tags = make(map[string]string)
+			}
+			r.Tags[strings.TrimPrefix(header, tagHeaderPrefix)] = value
+		}
+		return
+	}
+
+	switch header {
+	case "Unique Asset Identifier":
+		r.UniqueAssetIdentifier = value
+	case "IPv4 or IPv6 Address":
+		r.IPv4orIPv6Address = value
+	case "Virtual":
+		r.Virtual, err = parseBool(value)
+	case "Public":
+		r.Public, err = parseBool(value)
+	case "DNS Name or URL":
+		r.DNSNameOrURL = value
+	case "NetBIOS Name":
+		r.NetBIOSName = value
+	case "MAC Address":
+		r.MACAddress = value
+	case "Authenticated Scan":
+		r.AuthenticatedScan, err = parseBool(value)
+	case "Baseline Configuration Name":
+		r.BaselineConfigurationName = value
+	case "OS Name and Version":
+		r.OSNameAndVersion = value
+	case "Location":
+		r.Location = value
+	case "Asset Type":
+		r.AssetType = value
+	case "Hardware Make/Model":
+		r.HardwareMakeModel = value
+	case "In Latest Scan":
+		r.InLatestScan, err = parseBool(value)
+	case "Software/Database Vendor":
+		r.SoftwareDatabaseVendor = value
+	case "Software/Database Name & Version":
+		r.SoftwareDatabaseNameAndVersion = value
+	case "Patch Level":
+		r.PatchLevel = value
+	case "Function":
+		r.Function = value
+	case "Comments":
+		r.Comments = value
+	case "Serial #/Asset Tag #":
+		r.SerialAssetTagNumber = value
+	case "VLAN/Network ID":
+		r.VLANNetworkID = value
+	case "System Administrator/Owner":
+		r.SystemAdministratorOwner = value
+	case "ApplicationAdministrator/Owner":
+		r.ApplicationAdministratorOwner = value
+	case "Account ID":
+		r.AccountID = value
+	case "Account Alias":
+		r.AccountAlias = value
+	}
+
+	return
+}
+
+// parseBool returns true for Yes and false for No or an empty value, as written by getBoolString
+func parseBool(value string) (bool, error) {
+	switch value {
+	case "Yes":
+		return true, nil
+	case "No", "":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w, got %q", ErrInvalidBool, value)
+	}
+}
@@ -0,0 +1,137 @@
+package inventory
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSVReturnsRowsWritten(t *testing.T) {
+	var buf bytes.Buffer
+
+	c, err := NewCSV(&buf, WithAccountColumns())
+	require.NoError(t, err)
+	require.NoError(t, c.WriteRow(testRow))
+	require.NoError(t, c.Close())
+
+	rows, err := ReadCSV(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []Row{testRow}, rows)
+}
+
+func TestReadCSVReturnsTagsWritten(t *testing.T) {
+	var buf bytes.Buffer
+
+	row := testRow
+	row.Tags = map[string]string{"Owner": "test owner"}
+
+	c, err := NewCSV(&buf, WithAccountColumns(), WithTagColumns("Owner", "CostCenter"))
+	require.NoError(t, err)
+	require.NoError(t, c.WriteRow(row))
+	require.NoError(t, c.Close())
+
+	rows, err := ReadCSV(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []Row{row}, rows)
+}
+
+func TestReadJSONReturnsRowsWritten(t *testing.T) {
+	var buf bytes.Buffer
+
+	j, err := NewJSON(&buf)
+	require.NoError(t, err)
+	require.NoError(t, j.WriteRow(newTestJSONRow()))
+	require.NoError(t, j.Close())
+
+	rows, err := ReadJSON(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []Row{newTestJSONRow()}, rows)
+}
+
+func TestReadJSONReturnsRowsWrittenAsJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+
+	j, err := NewJSONL(&buf)
+	require.NoError(t, err)
+	require.NoError(t, j.WriteRow(newTestJSONRow()))
+	require.NoError(t, j.WriteRow(newTestJSONRow()))
+	require.NoError(t, j.Close())
+
+	rows, err := ReadJSON(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []Row{newTestJSONRow(), newTestJSONRow()}, rows)
+}
+
+func TestReadCSVAllowsColumnsInAnyOrder(t *testing.T) {
+	rows, err := ReadCSV(strings.NewReader("\ufeffVirtual,Unique Asset Identifier,Public\nYes,test-asset,\n"))
+
+	require.NoError(t, err)
+	require.Equal(t, []Row{{UniqueAssetIdentifier: "test-asset", Virtual: true}}, rows)
+}
+
+func TestReadCSVReturnsErrorForEmptyFile(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader(""))
+
+	require.True(t, errors.Is(err, ErrInvalidHeaders))
+}
+
+func TestReadCSVReturnsErrorForUnknownHeading(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("Unique Asset Identifier,Colour\n"))
+
+	var readErr *ReadError
+	require.True(t, errors.As(err, &readErr))
+	require.True(t, errors.Is(err, ErrInvalidHeaders))
+	require.Equal(t, 1, readErr.Row)
+	require.Equal(t, "Colour", readErr.Column)
+}
+
+func TestReadCSVReturnsErrorForRepeatedHeading(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("Unique Asset Identifier,Virtual,Virtual\n"))
+
+	require.True(t, errors.Is(err, ErrInvalidHeaders))
+}
+
+func TestReadCSVReturnsErrorForMissingUniqueAssetIdentifier(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("Virtual\nYes\n"))
+
+	require.True(t, errors.Is(err, ErrInvalidHeaders))
+}
+
+func TestReadCSVReturnsErrorForInvalidBool(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("Unique Asset Identifier,Public\ntest-asset-1,No\ntest-asset-2,true\n"))
+
+	var readErr *ReadError
+	require.True(t, errors.As(err, &readErr))
+	require.True(t, errors.Is(err, ErrInvalidBool))
+	require.Equal(t, 3, readErr.Row)
+	require.Equal(t, "Public", readErr.Column)
+	require.Equal(t, `row 3, column "Public": expected Yes or No, got "true"`, err.Error())
+}
+
+func TestReadCSVReturnsErrorForWrongNumberOfFields(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("Unique Asset Identifier,Public\ntest-asset,No,extra\n"))
+
+	require.Error(t, err)
+}
+
+func TestReadYAMLReturnsRows(t *testing.T) {
+	rows, err := ReadYAML(strings.NewReader(`
+- unique_asset_identifier: test-laptop
+  ip_addresses:
+    - 10.0.0.1
+    - 10.0.0.2
+  virtual: false
+  asset_type: Laptop
+- unique_asset_identifier: test-appliance
+  public: true
+`))
+
+	require.NoError(t, err)
+	require.Equal(t, []Row{
+		{UniqueAssetIdentifier: "test-laptop", IPv4orIPv6Address: "10.0.0.1\n10.0.0.2", AssetType: "Laptop"},
+		{UniqueAssetIdentifier: "test-appliance", Public: true},
+	}, rows)
+}
@@ -1,5 +1,43 @@
 package inventory
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownField is returned by SetField when no field has the given name
+var ErrUnknownField = errors.New("unknown field")
+
+// fieldHeaders holds the column heading of each field, keyed by the name used in the json format
+var fieldHeaders = map[string]string{
+	"unique_asset_identifier":            "Unique Asset Identifier",
+	"ip_addresses":                       "IPv4 or IPv6 Address",
+	"virtual":                            "Virtual",
+	"public":                             "Public",
+	"dns_names":                          "DNS Name or URL",
+	"netbios_name":                       "NetBIOS Name",
+	"mac_addresses":                      "MAC Address",
+	"authenticated_scan":                 "Authenticated Scan",
+	"baseline_configuration_name":        "Baseline Configuration Name",
+	"os_name_and_version":                "OS Name and Version",
+	"location":                           "Location",
+	"asset_type":                         "Asset Type",
+	"hardware_make_model":                "Hardware Make/Model",
+	"in_latest_scan":                     "In Latest Scan",
+	"software_database_vendor":           "Software/Database Vendor",
+	"software_database_name_and_version": "Software/Database Name & Version",
+	"patch_level":                        "Patch Level",
+	"function":                           "Function",
+	"comments":                           "Comments",
+	"serial_asset_tag_number":            "Serial #/Asset Tag #",
+	"vlan_network_id":                    "VLAN/Network ID",
+	"system_administrator_owner":         "System Administrator/Owner",
+	"application_administrator_owner":    "ApplicationAdministrator/Owner",
+	"account_id":                         "Account ID",
+	"account_alias":                      "Account Alias",
+}
+
 // Row represents a row in the report
 type Row struct {
 	UniqueAssetIdentifier          string
@@ -25,6 +63,35 @@ type Row struct {
 	VLANNetworkID                  string
 	SystemAdministratorOwner       string
 	ApplicationAdministratorOwner  string
+	AccountID                      string
+	AccountAlias                   string
+
+	// Tags holds the tags of the resource, for collectors of services which support them
+	Tags map[string]string
+}
+
+// Fields returns the names of the fields which can be set with SetField, as used in the json format, in
+// alphabetical order
+func Fields() []string {
+	var names []string
+	for name := range fieldHeaders {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// SetField sets the field with the given name, as used in the json format, to the value.
+// Fields holding Yes or No only accept those values, and fields holding several values take one per line.
+func (r *Row) SetField(name, value string) error {
+	header, ok := fieldHeaders[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownField, name)
+	}
+
+	return setField(r, header, value)
 }
 
 // StringSlice returns a slice of strings representing the fields on the Row
@@ -54,6 +121,8 @@ func (r Row) StringSlice() []string {
 	record = append(record, r.VLANNetworkID)
 	record = append(record, r.SystemAdministratorOwner)
 	record = append(record, r.ApplicationAdministratorOwner)
+	record = append(record, r.AccountID)
+	record = append(record, r.AccountAlias)
 
 	return record
 }
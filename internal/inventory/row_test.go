@@ -1,6 +1,7 @@
 package inventory
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -30,6 +31,8 @@ var testRow = Row{
 	VLANNetworkID:                  "VLANNetworkID",
 	SystemAdministratorOwner:       "SystemAdministratorOwner",
 	ApplicationAdministratorOwner:  "ApplicationAdministratorOwner",
+	AccountID:                      "AccountID",
+	AccountAlias:                   "AccountAlias",
 }
 
 func TestRowCanReturnSliceOfStrings(t *testing.T) {
@@ -59,7 +62,32 @@ func TestRowCanReturnSliceOfStrings(t *testing.T) {
 		testRow.VLANNetworkID,
 		testRow.SystemAdministratorOwner,
 		testRow.ApplicationAdministratorOwner,
+		testRow.AccountID,
+		testRow.AccountAlias,
 	}
 
 	require.Equal(t, expected, actual)
 }
+
+func TestFieldsHaveAColumnEach(t *testing.T) {
+	require.Len(t, Fields(), len(csvHeaders))
+
+	for _, name := range Fields() {
+		require.Contains(t, csvHeaders, fieldHeaders[name])
+	}
+}
+
+func TestRowCanSetFieldByName(t *testing.T) {
+	var row Row
+
+	require.NoError(t, row.SetField("system_administrator_owner", "ops@example.com"))
+	require.NoError(t, row.SetField("public", "Yes"))
+
+	require.Equal(t, Row{SystemAdministratorOwner: "ops@example.com", Public: true}, row)
+}
+
+func TestRowSetFieldReturnsErrUnknownField(t *testing.T) {
+	var row Row
+
+	require.True(t, errors.Is(row.SetField("owner", "ops@example.com"), ErrUnknownField))
+}
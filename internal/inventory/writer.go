@@ -0,0 +1,153 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownFormat is returned by NewWriter when no format is registered with the given name
+var ErrUnknownFormat = errors.New("unknown format")
+
+// Writer writes rows to an inventory in a particular format
+type Writer interface {
+	// WriteRow writes the row to the inventory
+	WriteRow(r Row) error
+
+	// Close finishes the inventory, writing anything still buffered. It does not close the underlying writer.
+	Close() error
+}
+
+// NewWriterFunc returns a new Writer for a format, writing the inventory to the given writer
+type NewWriterFunc func(w io.Writer, opts ...WriterOption) (Writer, error)
+
+// WriterOption configures a Writer
+type WriterOption func(*writerOptions)
+
+type writerOptions struct {
+	accountColumns bool
+	tagColumns     []string
+}
+
+// WithAccountColumns adds the account ID and alias of every row as extra columns after those of the FedRAMP
+// template in the csv and xlsx formats, for inventories gathered from several accounts
+func WithAccountColumns() WriterOption {
+	return func(o *writerOptions) {
+		o.accountColumns = true
+	}
+}
+
+// WithTagColumns adds the values of the given tag keys to every row, as extra columns headed "Tag: " and the key
+// in the csv and xlsx formats, and as a tags object in the json formats
+func WithTagColumns(keys ...string) WriterOption {
+	return func(o *writerOptions) {
+		o.tagColumns = append(o.tagColumns, keys...)
+	}
+}
+
+func newWriterOptions(opts []WriterOption) writerOptions {
+	var o writerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// tagHeaderPrefix starts the heading of a column holding the values of a tag
+const tagHeaderPrefix = "Tag: "
+
+// headers returns the column headings of the template, followed by those of any account and tag columns
+func (o writerOptions) headers() []string {
+	headers := make([]string, 0, len(csvHeaders)+len(o.tagColumns))
+	headers = append(headers, templateHeaders...)
+	if o.accountColumns {
+		headers = append(headers, accountHeaders...)
+	}
+	for _, key := range o.tagColumns {
+		headers = append(headers, tagHeaderPrefix+key)
+	}
+
+	return headers
+}
+
+// record returns the values of the row's fields in the template, followed by those of any account and tag columns
+func (o writerOptions) record(r Row) []string {
+	record := r.StringSlice()
+	if !o.accountColumns {
+		record = record[:len(templateHeaders)]
+	}
+	for _, key := range o.tagColumns {
+		record = append(record, r.Tags[key])
+	}
+
+	return record
+}
+
+// tags returns the row's tags with keys in the tag columns, or nil when there are no tag columns
+func (o writerOptions) tags(r Row) map[string]string {
+	if len(o.tagColumns) == 0 {
+		return nil
+	}
+
+	tags := make(map[string]string)
+	for _, key := range o.tagColumns {
+		if value, ok := r.Tags[key]; ok {
+			tags[key] = value
+		}
+	}
+
+	return tags
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = make(map[string]NewWriterFunc)
+)
+
+// RegisterFormat makes a format available to NewWriter by its name.
+// It panics if the function is nil or a format with the same name is already registered.
+func RegisterFormat(name string, fn NewWriterFunc) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	if fn == nil {
+		panic("inventory: RegisterFormat function is nil")
+	}
+
+	if _, dup := formats[name]; dup {
+		panic(fmt.Sprintf("inventory: RegisterFormat called twice for format %s", name))
+	}
+
+	formats[name] = fn
+}
+
+// Formats returns the names of the registered formats in alphabetical order
+func Formats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	var list []string
+	for name := range formats {
+		list = append(list, name)
+	}
+
+	sort.Strings(list)
+
+	return list
+}
+
+// NewWriter returns a new Writer for the named format, writing the inventory to the given writer
+func NewWriter(format string, w io.Writer, opts ...WriterOption) (Writer, error) {
+	formatsMu.RLock()
+	fn, ok := formats[format]
+	formatsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+
+	return fn(w, opts...)
+}
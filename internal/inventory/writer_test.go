@@ -0,0 +1,72 @@
+package inventory
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testWriter struct {
+	rows []Row
+}
+
+func (w *testWriter) WriteRow(r Row) error {
+	w.rows = append(w.rows, r)
+	return nil
+}
+
+func (w *testWriter) Close() error {
+	return nil
+}
+
+func init() {
+	RegisterFormat("test", func(w io.Writer, opts ...WriterOption) (Writer, error) {
+		return &testWriter{}, nil
+	})
+}
+
+func TestFormatsIncludesRegisteredFormats(t *testing.T) {
+	formats := Formats()
+
+	require.Contains(t, formats, FormatCSV)
+	require.Contains(t, formats, FormatXLSX)
+	require.Contains(t, formats, "test")
+}
+
+func TestNewWriterReturnsWriterForFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(FormatCSV, &buf)
+	require.NoError(t, err)
+	require.IsType(t, CSV{}, w)
+
+	w, err = NewWriter("test", &buf)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow(testRow))
+	require.Equal(t, []Row{testRow}, w.(*testWriter).rows)
+}
+
+func TestNewWriterReturnsErrUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	_, err := NewWriter("unknown", &buf)
+
+	require.True(t, errors.Is(err, ErrUnknownFormat))
+}
+
+func TestRegisterFormatPanicsOnDuplicate(t *testing.T) {
+	require.Panics(t, func() {
+		RegisterFormat(FormatCSV, func(w io.Writer, opts ...WriterOption) (Writer, error) {
+			return NewCSV(w)
+		})
+	})
+}
+
+func TestRegisterFormatPanicsOnNil(t *testing.T) {
+	require.Panics(t, func() {
+		RegisterFormat("nil", nil)
+	})
+}
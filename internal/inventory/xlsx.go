@@ -0,0 +1,275 @@
+package inventory
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FormatXLSX is the name of the xlsx format
+const FormatXLSX = "xlsx"
+
+func init() {
+	RegisterFormat(FormatXLSX, func(w io.Writer, opts ...WriterOption) (Writer, error) {
+		return NewXLSX(w, opts...)
+	})
+}
+
+// xlsxSheetName is the name of the worksheet holding the inventory, as in the FedRAMP template
+const xlsxSheetName = "Inventory"
+
+// xlsxTitle is written above the column headings, as in the FedRAMP template
+const xlsxTitle = "FedRAMP Integrated Inventory Workbook"
+
+// xlsxHeaderRows is the number of rows above the first row of the inventory
+const xlsxHeaderRows = 2
+
+// xlsxMaxRows is the number of rows in a worksheet, used for validations covering every row of the inventory
+const xlsxMaxRows = 1048576
+
+// xlsxColumnWidths holds the width of each column, in characters, keyed by heading
+var xlsxColumnWidths = map[string]float64{
+	"Unique Asset Identifier":          40,
+	"IPv4 or IPv6 Address":             20,
+	"Virtual":                          10,
+	"Public":                           10,
+	"DNS Name or URL":                  50,
+	"NetBIOS Name":                     20,
+	"MAC Address":                      20,
+	"Authenticated Scan":               15,
+	"Baseline Configuration Name":      25,
+	"OS Name and Version":              25,
+	"Location":                         15,
+	"Asset Type":                       25,
+	"Hardware Make/Model":              20,
+	"In Latest Scan":                   12,
+	"Software/Database Vendor":         20,
+	"Software/Database Name & Version": 25,
+	"Patch Level":                      15,
+	"Function":                         30,
+	"Comments":                         40,
+	"Serial #/Asset Tag #":             50,
+	"VLAN/Network ID":                  25,
+	"System Administrator/Owner":       25,
+	"ApplicationAdministrator/Owner":   25,
+	"Account ID":                       15,
+	"Account Alias":                    25,
+}
+
+// xlsxDefaultColumnWidth is the width of any column missing from xlsxColumnWidths
+const xlsxDefaultColumnWidth = 20
+
+// xlsxYesNoColumns are the columns restricted to Yes or No
+var xlsxYesNoColumns = []string{
+	"Virtual",
+	"Public",
+	"Authenticated Scan",
+	"In Latest Scan",
+}
+
+// Styles, indexing the cellXfs in xlsxStyles
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleTitle
+	xlsxStyleHeader
+	xlsxStyleCell
+)
+
+// XLSX handles an xlsx format inventory, laid out as the FedRAMP Integrated Inventory Workbook.
+// Rows are streamed to the underlying writer as they are written.
+type XLSX struct {
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	rows    int
+	options writerOptions
+	headers []string
+}
+
+// NewXLSX returns a new xlsx object ready to have rows written to it
+func NewXLSX(writer io.Writer, opts ...WriterOption) (x *XLSX, err error) {
+	x = &XLSX{
+		zip:     zip.NewWriter(writer),
+		options: newWriterOptions(opts),
+	}
+	x.headers = x.options.headers()
+
+	err = x.writeParts()
+	if err != nil {
+		return
+	}
+
+	err = x.writeHeaders()
+
+	return
+}
+
+// writeParts writes the parts of the workbook other than the worksheet, then starts the worksheet
+func (x *XLSX) writeParts() error {
+	parts := []struct {
+		name, content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xlsxSheetName)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+
+	for _, part := range parts {
+		w, err := x.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return err
+		}
+	}
+
+	w, err := x.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	x.sheet = bufio.NewWriter(w)
+
+	return nil
+}
+
+// writeHeaders writes the start of the worksheet, the title and the column headings
+func (x *XLSX) writeHeaders() error {
+	x.sheet.WriteString(xml.Header)
+	x.sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	fmt.Fprintf(x.sheet, `<sheetViews><sheetView workbookViewId="0"><pane ySplit="%d" topLeftCell="A%d" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`, xlsxHeaderRows, xlsxHeaderRows+1)
+
+	x.sheet.WriteString(`<cols>`)
+	for i, header := range x.headers {
+		width, ok := xlsxColumnWidths[header]
+		if !ok {
+			width = xlsxDefaultColumnWidth
+		}
+		fmt.Fprintf(x.sheet, `<col min="%d" max="%d" width="%g" customWidth="1"/>`, i+1, i+1, width)
+	}
+	x.sheet.WriteString(`</cols><sheetData>`)
+
+	x.writeCells([]string{xlsxTitle}, xlsxStyleTitle)
+	x.writeCells(x.headers, xlsxStyleHeader)
+
+	return x.sheet.Flush()
+}
+
+// WriteRow writes the row to the worksheet
+func (x *XLSX) WriteRow(r Row) error {
+	x.writeCells(x.options.record(r), xlsxStyleCell)
+
+	if x.sheet.Buffered() > x.sheet.Size()/2 {
+		return x.sheet.Flush()
+	}
+
+	return nil
+}
+
+// Close writes the end of the worksheet, including the Yes/No validations, and finishes the workbook.
+// It does not close the underlying writer.
+func (x *XLSX) Close() error {
+	x.sheet.WriteString(`</sheetData>`)
+	fmt.Fprintf(x.sheet, `<mergeCells count="1"><mergeCell ref="A1:%s1"/></mergeCells>`, xlsxColumnName(len(x.headers)-1))
+
+	fmt.Fprintf(x.sheet, `<dataValidations count="%d">`, len(xlsxYesNoColumns))
+	for _, header := range xlsxYesNoColumns {
+		column := xlsxColumnName(indexOf(x.headers, header))
+		fmt.Fprintf(x.sheet, `<dataValidation type="list" allowBlank="1" showErrorMessage="1" sqref="%s%d:%s%d"><formula1>"Yes,No"</formula1></dataValidation>`, column, xlsxHeaderRows+1, column, xlsxMaxRows)
+	}
+	x.sheet.WriteString(`</dataValidations></worksheet>`)
+
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return x.zip.Close()
+}
+
+// writeCells writes a row of cells with the given style. Errors are left in the buffered writer, to be returned
+// when it is flushed.
+func (x *XLSX) writeCells(values []string, style int) {
+	x.rows++
+
+	fmt.Fprintf(x.sheet, `<row r="%d">`, x.rows)
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+
+		fmt.Fprintf(x.sheet, `<c r="%s%d" s="%d" t="inlineStr"><is><t xml:space="preserve">`, xlsxColumnName(i), x.rows, style)
+		xml.EscapeText(x.sheet, []byte(value))
+		x.sheet.WriteString(`</t></is></c>`)
+	}
+	x.sheet.WriteString(`</row>`)
+}
+
+// xlsxColumnName returns the letters naming the column with the given zero based index, e.g. 0 is A and 26 is AA
+func xlsxColumnName(i int) string {
+	var name []string
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = append([]string{string(rune('A' + (i-1)%26))}, name...)
+	}
+
+	return strings.Join(name, "")
+}
+
+func indexOf(s []string, v string) int {
+	for i := range s {
+		if s[i] == v {
+			return i
+		}
+	}
+
+	return -1
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="3">` +
+	`<font><sz val="11"/><name val="Calibri"/></font>` +
+	`<font><b/><sz val="14"/><name val="Calibri"/></font>` +
+	`<font><b/><sz val="11"/><name val="Calibri"/></font>` +
+	`</fonts>` +
+	`<fills count="3">` +
+	`<fill><patternFill patternType="none"/></fill>` +
+	`<fill><patternFill patternType="gray125"/></fill>` +
+	`<fill><patternFill patternType="solid"><fgColor rgb="FFD9E1F2"/></patternFill></fill>` +
+	`</fills>` +
+	`<borders count="2">` +
+	`<border><left/><right/><top/><bottom/><diagonal/></border>` +
+	`<border><left style="thin"/><right style="thin"/><top style="thin"/><bottom style="thin"/><diagonal/></border>` +
+	`</borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="4">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="0" fontId="2" fillId="2" borderId="1" xfId="0" applyFont="1" applyFill="1" applyBorder="1" applyAlignment="1"><alignment horizontal="center" vertical="center" wrapText="1"/></xf>` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="1" xfId="0" applyBorder="1" applyAlignment="1"><alignment vertical="top" wrapText="1"/></xf>` +
+	`</cellXfs>` +
+	`</styleSheet>`
@@ -0,0 +1,154 @@
+package inventory
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// xlsxTestSheet holds the parts of a worksheet checked by the tests
+type xlsxTestSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref   string `xml:"r,attr"`
+			Value string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+	DataValidations []struct {
+		Ref     string `xml:"sqref,attr"`
+		Formula string `xml:"formula1"`
+	} `xml:"dataValidations>dataValidation"`
+}
+
+func readXLSXSheet(t *testing.T, b []byte) (sheet xlsxTestSheet) {
+	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := ioutil.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+
+		var v interface{}
+		require.NoError(t, xml.Unmarshal(content, &v), "%s is not valid xml", f.Name)
+
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			require.NoError(t, xml.Unmarshal(content, &sheet))
+		}
+	}
+
+	return
+}
+
+func TestNewXLSXHasHeaders(t *testing.T) {
+	var buf bytes.Buffer
+
+	x, err := NewXLSX(&buf)
+	require.NoError(t, err)
+	require.NoError(t, x.Close())
+
+	sheet := readXLSXSheet(t, buf.Bytes())
+
+	require.Len(t, sheet.Rows, xlsxHeaderRows)
+	require.Equal(t, xlsxTitle, sheet.Rows[0].Cells[0].Value)
+
+	var headers []string
+	for _, c := range sheet.Rows[1].Cells {
+		headers = append(headers, c.Value)
+	}
+	require.Equal(t, templateHeaders, headers)
+}
+
+func TestNewXLSXWritesRow(t *testing.T) {
+	var buf bytes.Buffer
+
+	row := testRow
+	row.Comments = "first line\nsecond line & more"
+
+	x, err := NewXLSX(&buf)
+	require.NoError(t, err)
+	require.NoError(t, x.WriteRow(row))
+	require.NoError(t, x.Close())
+
+	sheet := readXLSXSheet(t, buf.Bytes())
+
+	require.Len(t, sheet.Rows, xlsxHeaderRows+1)
+
+	var values []string
+	for _, c := range sheet.Rows[2].Cells {
+		values = append(values, c.Value)
+	}
+	require.Equal(t, row.StringSlice()[:len(templateHeaders)], values)
+	require.Equal(t, "A3", sheet.Rows[2].Cells[0].Ref)
+}
+
+func TestNewXLSXValidatesYesNoColumns(t *testing.T) {
+	var buf bytes.Buffer
+
+	x, err := NewXLSX(&buf)
+	require.NoError(t, err)
+	require.NoError(t, x.Close())
+
+	sheet := readXLSXSheet(t, buf.Bytes())
+
+	require.Len(t, sheet.DataValidations, len(xlsxYesNoColumns))
+	require.Equal(t, "C3:C1048576", sheet.DataValidations[0].Ref)
+	require.Equal(t, `"Yes,No"`, sheet.DataValidations[0].Formula)
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	require.Equal(t, "A", xlsxColumnName(0))
+	require.Equal(t, "Z", xlsxColumnName(25))
+	require.Equal(t, "AA", xlsxColumnName(26))
+	require.Equal(t, "AZ", xlsxColumnName(51))
+	require.Equal(t, "BA", xlsxColumnName(52))
+}
+
+func TestNewXLSXWritesAccountColumns(t *testing.T) {
+	var buf bytes.Buffer
+
+	x, err := NewXLSX(&buf, WithAccountColumns())
+	require.NoError(t, err)
+	require.NoError(t, x.WriteRow(testRow))
+	require.NoError(t, x.Close())
+
+	sheet := readXLSXSheet(t, buf.Bytes())
+
+	var headers, values []string
+	for _, c := range sheet.Rows[1].Cells {
+		headers = append(headers, c.Value)
+	}
+	for _, c := range sheet.Rows[2].Cells {
+		values = append(values, c.Value)
+	}
+	require.Equal(t, csvHeaders, headers)
+	require.Equal(t, testRow.StringSlice(), values)
+}
+
+func TestNewXLSXWritesTagColumns(t *testing.T) {
+	var buf bytes.Buffer
+
+	row := testRow
+	row.Tags = map[string]string{"Owner": "test owner"}
+
+	x, err := NewXLSX(&buf, WithTagColumns("Owner"))
+	require.NoError(t, err)
+	require.NoError(t, x.WriteRow(row))
+	require.NoError(t, x.Close())
+
+	sheet := readXLSXSheet(t, buf.Bytes())
+
+	headers := sheet.Rows[1].Cells
+	require.Equal(t, "Tag: Owner", headers[len(headers)-1].Value)
+	require.Equal(t, xlsxColumnName(len(templateHeaders))+"2", headers[len(headers)-1].Ref)
+
+	values := sheet.Rows[2].Cells
+	require.Equal(t, "test owner", values[len(values)-1].Value)
+	require.Equal(t, xlsxColumnName(len(templateHeaders))+"3", values[len(values)-1].Ref)
+}